@@ -0,0 +1,289 @@
+package rbxdump
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultError indicates that a default value could not be parsed according to
+// its declared type.
+type DefaultError interface {
+	error
+	// DefaultError returns the declared type and the value that could not be
+	// parsed.
+	DefaultError() (typ Type, value string)
+}
+
+// errDefault implements the DefaultError interface.
+type errDefault struct {
+	typ   Type
+	value string
+	msg   string
+}
+
+func (err errDefault) Error() string {
+	return "default " + strconv.Quote(err.value) + " of type " + err.typ.String() + ": " + err.msg
+}
+
+func (err errDefault) DefaultError() (typ Type, value string) {
+	return err.typ, err.value
+}
+
+// Vector3 is the value of a default of the Vector3 type.
+type Vector3 struct {
+	X, Y, Z float64
+}
+
+// Color3 is the value of a default of the Color3 type, with components in the
+// range 0 to 1.
+type Color3 struct {
+	R, G, B float64
+}
+
+// NoDefault is the value of an empty default, which indicates the absence of a
+// default. It is distinct from nil, which is the value of "nil".
+type NoDefault struct{}
+
+// EnumItemValue is the value of a default of an Enum type.
+type EnumItemValue struct {
+	// Enum is the name of the enum.
+	Enum string
+	// Name is the name of the item. Empty if the item is referred to by
+	// value.
+	Name string
+	// Value is the value of the item, if Name is empty.
+	Value int
+}
+
+// ParseDefault parses a default value, as found in Property.Default or
+// Parameter.Default, according to the declared type. The type of the returned
+// value depends on the declared type:
+//
+//   - nil, for "nil", which is valid for optional types, classes, and the
+//     Variant and Tuple groups.
+//   - bool, for the bool type.
+//   - int64, for the int and int64 types.
+//   - float64, for the float and double types. The value "math.huge" is
+//     infinity.
+//   - string, for the string type. The value may be quoted, as in Go. The
+//     empty string must be quoted, and is written as two quote marks.
+//   - Vector3, for the Vector3 type, written as "Vector3.new(x, y, z)",
+//     "x, y, z", "Vector3.zero", or "Vector3.one".
+//   - Color3, for the Color3 type, written as "Color3.new(r, g, b)",
+//     "Color3.fromRGB(r, g, b)", or "r, g, b".
+//   - EnumItemValue, for types of the Enum category, written as the name of
+//     the item, optionally qualified as "Enum.Name.Item", or as the value of the
+//     item.
+//
+// An empty string indicates the absence of a default, and returns NoDefault.
+// The string of any other type is returned unparsed. Returns a DefaultError if
+// the value does not match the type.
+func ParseDefault(typ Type, s string) (v any, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return NoDefault{}, nil
+	}
+	fail := func(msg string) (any, error) {
+		return nil, errDefault{typ: typ, value: s, msg: msg}
+	}
+	if s == "nil" {
+		switch {
+		case typ.Optional,
+			typ.Category == CategoryClass,
+			typ.Kind() == KindVariant,
+			typ.Kind() == KindTuple:
+			return nil, nil
+		}
+		return fail("nil is not valid for a required type")
+	}
+	if typ.Category == CategoryEnum {
+		return parseEnumDefault(typ, s, fail)
+	}
+	if typ.Category == CategoryClass {
+		return fail("expected nil")
+	}
+	switch typ.Name {
+	case "void":
+		return fail("void cannot have a value")
+	case "bool":
+		switch s {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return fail("expected true or false")
+	case "int", "int64":
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fail("expected integer")
+		}
+		return n, nil
+	case "float", "double":
+		n, ok := parseNumber(s)
+		if !ok {
+			return fail("expected number")
+		}
+		return n, nil
+	case "string":
+		if len(s) >= 2 && s[0] == '"' {
+			u, err := strconv.Unquote(s)
+			if err != nil {
+				return fail("malformed string")
+			}
+			return u, nil
+		}
+		return s, nil
+	case "Vector3":
+		switch s {
+		case "Vector3.zero":
+			return Vector3{}, nil
+		case "Vector3.one":
+			return Vector3{1, 1, 1}, nil
+		}
+		c, ok := parseComponents(s, "Vector3.new")
+		if !ok {
+			return fail("expected Vector3")
+		}
+		return Vector3{c[0], c[1], c[2]}, nil
+	case "Color3":
+		if c, ok := parseComponents(s, "Color3.fromRGB"); ok {
+			return Color3{c[0] / 255, c[1] / 255, c[2] / 255}, nil
+		}
+		c, ok := parseComponents(s, "Color3.new")
+		if !ok {
+			return fail("expected Color3")
+		}
+		return Color3{c[0], c[1], c[2]}, nil
+	}
+	return s, nil
+}
+
+// parseEnumDefault parses a default of an Enum type.
+func parseEnumDefault(typ Type, s string, fail func(string) (any, error)) (any, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return EnumItemValue{Enum: typ.Name, Value: n}, nil
+	}
+	name := s
+	if rest, ok := strings.CutPrefix(s, "Enum."); ok {
+		enum, item, ok := strings.Cut(rest, ".")
+		if !ok {
+			return fail("expected enum item")
+		}
+		if enum != typ.Name {
+			return fail("refers to enum \"" + enum + "\"")
+		}
+		name = item
+	}
+	if !isTypeName(name) || strings.Contains(name, ".") {
+		return fail("expected enum item")
+	}
+	return EnumItemValue{Enum: typ.Name, Name: name}, nil
+}
+
+// parseNumber parses a Luau number, including infinite values.
+func parseNumber(s string) (float64, bool) {
+	switch s {
+	case "math.huge", "inf", "+inf":
+		return math.Inf(1), true
+	case "-math.huge", "-inf":
+		return math.Inf(-1), true
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return 0, false
+	}
+	return n, true
+}
+
+// parseComponents parses three numeric components, either written as a
+// comma-separated list, or as arguments to the given constructor. A
+// constructor without arguments produces zero components.
+func parseComponents(s, constructor string) (c [3]float64, ok bool) {
+	if args, ok := strings.CutPrefix(s, constructor); ok {
+		args = strings.TrimSpace(args)
+		if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+			return c, false
+		}
+		s = strings.TrimSpace(args[1 : len(args)-1])
+		if s == "" {
+			return c, true
+		}
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) != len(c) {
+		return c, false
+	}
+	for i, part := range parts {
+		if c[i], ok = parseNumber(strings.TrimSpace(part)); !ok {
+			return c, false
+		}
+	}
+	return c, true
+}
+
+// FormatDefault formats a value returned by ParseDefault according to the
+// declared type. Each value has one formatting, so formatting a parsed default
+// normalizes its spelling. A string is quoted only if it would otherwise be
+// parsed as a different value, as for the empty string, "nil", or a string
+// with surrounding spaces or a leading quote. NoDefault is formatted as an
+// empty string.
+func FormatDefault(typ Type, v any) string {
+	switch v := v.(type) {
+	case NoDefault:
+		return ""
+	case nil:
+		return "nil"
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return formatNumber(v)
+	case string:
+		if typ.Name == "string" && needsQuote(v) {
+			return strconv.Quote(v)
+		}
+		return v
+	case Vector3:
+		return "Vector3.new(" + formatNumber(v.X) + ", " + formatNumber(v.Y) + ", " + formatNumber(v.Z) + ")"
+	case Color3:
+		return "Color3.new(" + formatNumber(v.R) + ", " + formatNumber(v.G) + ", " + formatNumber(v.B) + ")"
+	case EnumItemValue:
+		if v.Name == "" {
+			return strconv.Itoa(v.Value)
+		}
+		return "Enum." + v.Enum + "." + v.Name
+	}
+	return ""
+}
+
+// needsQuote returns whether a string default must be quoted to be parsed as
+// the same string.
+func needsQuote(s string) bool {
+	return s == "" || s == "nil" || s[0] == '"' || strings.TrimSpace(s) != s
+}
+
+// formatNumber formats a Luau number.
+func formatNumber(n float64) string {
+	switch {
+	case math.IsInf(n, 1):
+		return "math.huge"
+	case math.IsInf(n, -1):
+		return "-math.huge"
+	}
+	return strconv.FormatFloat(n, 'g', -1, 64)
+}
+
+// NormalizeDefault returns the normalized spelling of a default value
+// according to the declared type, such that equivalent values have equal
+// spellings. An empty string remains empty. If the value cannot be parsed,
+// then s is returned unchanged, along with a DefaultError.
+func NormalizeDefault(typ Type, s string) (string, error) {
+	v, err := ParseDefault(typ, s)
+	if err != nil {
+		return s, err
+	}
+	return FormatDefault(typ, v), nil
+}
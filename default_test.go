@@ -0,0 +1,65 @@
+package rbxdump
+
+import (
+	"testing"
+)
+
+func TestParseDefault(t *testing.T) {
+	str := Type{Category: CategoryPrimitive, Name: "string"}
+	optional := Type{Category: CategoryPrimitive, Name: "string", Optional: true}
+	tests := []struct {
+		typ  Type
+		s    string
+		want any
+	}{
+		{str, "", NoDefault{}},
+		{str, "  ", NoDefault{}},
+		{str, `""`, ""},
+		{str, "global", "global"},
+		{str, `"global"`, "global"},
+		{str, `"nil"`, "nil"},
+		{optional, "nil", nil},
+		{Type{Name: "int"}, "", NoDefault{}},
+	}
+	for _, test := range tests {
+		got, err := ParseDefault(test.typ, test.s)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %v", test.typ, test.s, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s %q: got %#v, want %#v", test.typ, test.s, got, test.want)
+		}
+	}
+}
+
+func TestNormalizeDefault(t *testing.T) {
+	str := Type{Category: CategoryPrimitive, Name: "string"}
+	tests := []struct {
+		typ  Type
+		s    string
+		want string
+	}{
+		{str, "", ""},
+		{str, `""`, `""`},
+		{str, "global", "global"},
+		{str, `"global"`, "global"},
+		{str, `"nil"`, `"nil"`},
+		{str, `" padded "`, `" padded "`},
+		{Type{Category: CategoryPrimitive, Name: "float"}, "1.50", "1.5"},
+		{Type{Category: CategoryEnum, Name: "Material"}, "Plastic", "Enum.Material.Plastic"},
+	}
+	for _, test := range tests {
+		got, err := NormalizeDefault(test.typ, test.s)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %v", test.typ, test.s, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s %q: got %q, want %q", test.typ, test.s, got, test.want)
+		}
+		if again, _ := NormalizeDefault(test.typ, got); again != got {
+			t.Errorf("%s %q: normalized %q is not stable, got %q", test.typ, test.s, got, again)
+		}
+	}
+}
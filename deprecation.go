@@ -0,0 +1,211 @@
+package rbxdump
+
+import (
+	"sort"
+)
+
+// DeprecationError indicates that the chain of replacements of a deprecated
+// element could not be followed to an element that is not deprecated.
+type DeprecationError interface {
+	error
+	// DeprecationError returns the path of the element whose replacement could
+	// not be followed, and the name of the replacement. cycle is true if the
+	// replacement refers back to an element already in the chain, and false if
+	// the replacement does not exist.
+	DeprecationError() (path Path, replacement string, cycle bool)
+}
+
+// errDeprecation implements the DeprecationError interface.
+type errDeprecation struct {
+	path        Path
+	replacement string
+	cycle       bool
+}
+
+func (err errDeprecation) Error() string {
+	if err.cycle {
+		return "replacement \"" + err.replacement + "\" of " + err.path.String() + " forms a cycle"
+	}
+	return "replacement \"" + err.replacement + "\" of " + err.path.String() + " does not exist"
+}
+
+func (err errDeprecation) DeprecationError() (path Path, replacement string, cycle bool) {
+	return err.path, err.replacement, err.cycle
+}
+
+// Deprecations is a precomputed index of the deprecated elements of a Root.
+// An element is deprecated if its PreferredDescriptor has a Name, which refers
+// to the element's replacement. The replacement of a class or enum is the
+// class or enum of that name. The replacement of an enum item is the item of
+// that name within the same enum. The replacement of a member is the member
+// of that name resolved through the same class, as by LookupMember.
+//
+// The index is a snapshot; it does not reflect changes made to the Root after
+// it was created.
+type Deprecations struct {
+	// Resolved replacement of each deprecated element.
+	next map[Path]Path
+	// Name of the replacement of each deprecated element, resolved or not.
+	names map[Path]string
+	// Deprecated elements that refer to each replacement.
+	reverse map[Path][]Path
+}
+
+// NewDeprecations returns a Deprecations indexing root.
+func NewDeprecations(root *Root) *Deprecations {
+	d := &Deprecations{
+		next:    map[Path]Path{},
+		names:   map[Path]string{},
+		reverse: map[Path][]Path{},
+	}
+	add := func(path Path, name string, next Path, ok bool) {
+		if name == "" {
+			return
+		}
+		d.names[path] = name
+		if ok {
+			d.next[path] = next
+			d.reverse[next] = append(d.reverse[next], path)
+		}
+	}
+	for class := range root.IterClasses() {
+		pd := class.PreferredDescriptor.Name
+		c := root.Classes[pd]
+		add(ClassPath(class.Name), pd, ClassPath(pd), c != nil)
+		for member := range class.IterMembers() {
+			pd := memberPreferredDescriptor(member).Name
+			if pd == "" {
+				continue
+			}
+			var next Path
+			m, decl, _ := root.LookupMember(class.Name, pd)
+			if m != nil {
+				next = MemberPath(decl.Name, m)
+			}
+			add(MemberPath(class.Name, member), pd, next, m != nil)
+		}
+	}
+	for enum := range root.IterEnums() {
+		pd := enum.PreferredDescriptor.Name
+		add(EnumPath(enum.Name), pd, EnumPath(pd), root.Enums[pd] != nil)
+		for item := range enum.IterEnumItems() {
+			pd := item.PreferredDescriptor.Name
+			add(EnumItemPath(enum.Name, item.Name), pd, EnumItemPath(enum.Name, pd), enum.Items[pd] != nil)
+		}
+	}
+	for _, list := range d.reverse {
+		sort.Sort(sortPaths(list))
+	}
+	return d
+}
+
+// sortPaths sorts a list of paths by location.
+type sortPaths []Path
+
+func (a sortPaths) Len() int           { return len(a) }
+func (a sortPaths) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a sortPaths) Less(i, j int) bool { return a[i].Less(a[j]) }
+
+// IsDeprecated returns whether the element located by path is deprecated.
+func (d *Deprecations) IsDeprecated(path Path) bool {
+	_, ok := d.names[path]
+	return ok
+}
+
+// Deprecated returns the path of each deprecated element, ordered by
+// location.
+func (d *Deprecations) Deprecated() []Path {
+	list := make([]Path, 0, len(d.names))
+	for path := range d.names {
+		list = append(list, path)
+	}
+	sort.Sort(sortPaths(list))
+	return list
+}
+
+// Replacement returns the path of the direct replacement of the element
+// located by path. Returns false if the element is not deprecated, or if the
+// replacement does not exist.
+func (d *Deprecations) Replacement(path Path) (Path, bool) {
+	next, ok := d.next[path]
+	return next, ok
+}
+
+// Chain returns the replacements of the element located by path, in order,
+// ending with the first replacement that is not deprecated. Returns nil if
+// the element is not deprecated.
+//
+// If a replacement does not exist, or the chain is cyclic, then the
+// replacements resolved so far are returned along with a DeprecationError.
+func (d *Deprecations) Chain(path Path) (chain []Path, err error) {
+	visited := map[Path]bool{path: true}
+	for {
+		name, ok := d.names[path]
+		if !ok {
+			return chain, nil
+		}
+		next, ok := d.next[path]
+		if !ok {
+			return chain, errDeprecation{path: path, replacement: name}
+		}
+		if visited[next] {
+			return chain, errDeprecation{path: path, replacement: name, cycle: true}
+		}
+		visited[next] = true
+		chain = append(chain, next)
+		path = next
+	}
+}
+
+// Final returns the last element of the chain of replacements of the element
+// located by path, which is not deprecated. Returns false if the element is
+// not deprecated, or the chain cannot be followed.
+func (d *Deprecations) Final(path Path) (Path, bool) {
+	chain, err := d.Chain(path)
+	if err != nil || len(chain) == 0 {
+		return Path{}, false
+	}
+	return chain[len(chain)-1], true
+}
+
+// ReplacedBy returns the path of each deprecated element whose direct
+// replacement is the element located by path, ordered by location.
+func (d *Deprecations) ReplacedBy(path Path) []Path {
+	return append([]Path(nil), d.reverse[path]...)
+}
+
+// Problems returns a Problem for each deprecated element whose chain of
+// replacements cannot be followed, ordered by location. A cycle is reported
+// only for the elements that form the cycle.
+func (d *Deprecations) Problems() []Problem {
+	var problems []Problem
+	for _, path := range d.Deprecated() {
+		name := d.names[path]
+		next, ok := d.next[path]
+		if !ok {
+			problems = append(problems, Problem{
+				Path:  path,
+				Field: "PreferredDescriptor",
+				Msg:   "replacement \"" + name + "\" does not exist",
+			})
+			continue
+		}
+		// The element is part of a cycle if following the chain from its
+		// replacement leads back to it.
+		for visited := map[Path]bool{}; !visited[next]; {
+			visited[next] = true
+			if next == path {
+				problems = append(problems, Problem{
+					Path:  path,
+					Field: "PreferredDescriptor",
+					Msg:   "replacement \"" + name + "\" forms a cycle",
+				})
+				break
+			}
+			if next, ok = d.next[next]; !ok {
+				break
+			}
+		}
+	}
+	return problems
+}
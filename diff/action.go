@@ -5,6 +5,8 @@ package diff
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/robloxapi/rbxdump"
 )
@@ -29,6 +31,77 @@ type Patcher interface {
 	Patch([]Action)
 }
 
+// CheckedPatcher is implemented by a Patcher that can report the actions that
+// it could not apply.
+type CheckedPatcher interface {
+	Patcher
+	// PatchChecked is like Patch, but returns a PatchError listing each action
+	// with fields that could not be set, as reported by
+	// rbxdump.SetFieldsChecked. Such actions are otherwise applied as by Patch.
+	// Returns nil if no errors occurred.
+	PatchChecked([]Action) error
+}
+
+// StrictPatcher is implemented by a Patcher that can verify that each action
+// applies to the structure before applying any of them.
+type StrictPatcher interface {
+	Patcher
+	// PatchStrict applies the actions only if the preconditions of every
+	// action hold. Otherwise, the structure is left unchanged, and a
+	// PatchError is returned, listing each action that failed.
+	PatchStrict([]Action) error
+}
+
+// ActionError describes an Action that could not be applied.
+type ActionError struct {
+	// Index is the position of the action within the list of actions.
+	Index int
+	// Action is the action that could not be applied.
+	Action Action
+	// Err describes why the action could not be applied.
+	Err error
+}
+
+// Error implements the error interface.
+func (err ActionError) Error() string {
+	return "action " + strconv.Itoa(err.Index) + " (" + err.Action.location() + "): " + err.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err ActionError) Unwrap() error {
+	return err.Err
+}
+
+// PatchError lists each action that could not be applied, ordered by index.
+type PatchError []ActionError
+
+// Error implements the error interface.
+func (err PatchError) Error() string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(len(err)))
+	if len(err) == 1 {
+		b.WriteString(" action failed: ")
+	} else {
+		b.WriteString(" actions failed: ")
+	}
+	for i, e := range err {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(e.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns each ActionError as an error.
+func (err PatchError) Unwrap() []error {
+	errs := make([]error, len(err))
+	for i, e := range err {
+		errs[i] = e
+	}
+	return errs
+}
+
 // Inverter is implemented by any value that has an Inverse method, which
 // receives a list of Actions and produces an inverse list of Actions. That is,
 // for a list of actions P that patches structure A into B, the inverse of P
@@ -44,6 +117,8 @@ const (
 	Remove Type = -1 // The action removes data.
 	Change Type = 0  // The action changes data.
 	Add    Type = 1  // The action adds data.
+	Rename Type = 2  // The action renames an element.
+	Move   Type = 3  // The action moves a member to another class.
 )
 
 // String returns a string representation of the action type.
@@ -55,6 +130,10 @@ func (t Type) String() string {
 		return "Change"
 	case Add:
 		return "Add"
+	case Rename:
+		return "Rename"
+	case Move:
+		return "Move"
 	}
 	return "<invalid>"
 }
@@ -194,9 +273,20 @@ type Action struct {
 	// Secondary is the name of the secondary element. Applies only to Property,
 	// Function, Event, Callback, and EnumItem elements.
 	Secondary string `json:",omitempty"`
+	// Target is the new name of the element. Applies only to Rename and Move
+	// actions. For a Rename of a Class or Enum, this replaces Primary. For a
+	// Rename of other elements, this replaces Secondary. For a Move, this is
+	// the name of the class to which the member is moved from Primary.
+	Target string `json:",omitempty"`
 	// Fields describes fields of the element. If Type is Add, this describes
-	// the initial values. If Type is Change, this describes the new values.
+	// the initial values. If Type is Change, Rename, or Move, this describes
+	// the new values.
 	Fields rbxdump.Fields `json:",omitempty"`
+	// Prev optionally describes previous values of fields of the element. If
+	// Type is Remove, this describes each field of the removed element. If
+	// Type is Change, Rename, or Move, this describes the previous values of
+	// the fields in Fields. Does not apply to Add.
+	Prev rbxdump.Fields `json:",omitempty"`
 }
 
 // ToFielder returns a new element corresponding to the action's element type,
@@ -270,7 +360,9 @@ func (a *Action) UnmarshalJSON(b []byte) error {
 		Element   Element
 		Primary   string
 		Secondary string
+		Target    string
 		Fields    rbxdump.Fields
+		Prev      rbxdump.Fields
 	}
 	if err := json.Unmarshal(b, &action); err != nil {
 		return err
@@ -278,24 +370,41 @@ func (a *Action) UnmarshalJSON(b []byte) error {
 	if action.Fields == nil {
 		action.Fields = rbxdump.Fields{}
 	} else if len(action.Fields) > 0 {
-		// Convert generic JSON structure to rbxdump values.
-		if f := Action(action).ToFielder(); f != nil {
-			f.SetFields(action.Fields)
-			action.Fields = f.Fields(action.Fields)
-		} else {
-			action.Fields = rbxdump.Fields{}
-		}
+		action.Fields = Action(action).convertFields(action.Fields)
+	}
+	if len(action.Prev) > 0 {
+		action.Prev = Action(action).convertFields(action.Prev)
 	}
 	*a = Action(action)
 	return nil
 }
 
-func (a Action) String() string {
+// convertFields converts generic JSON structure to rbxdump values, according
+// to the action's element type.
+func (a Action) convertFields(fields rbxdump.Fields) rbxdump.Fields {
+	f := a.ToFielder()
+	if f == nil {
+		return rbxdump.Fields{}
+	}
+	f.SetFields(fields)
+	return f.Fields(fields)
+}
+
+// location returns the type of the action and the element it applies to.
+func (a Action) location() string {
 	s := a.Type.String() + " " + a.Element.String() + " " + a.Primary
 	switch a.Element {
 	case Property, Function, Event, Callback, EnumItem:
 		s += "." + a.Secondary
 	}
+	if a.Type == Rename || a.Type == Move {
+		s += " to " + a.Target
+	}
+	return s
+}
+
+func (a Action) String() string {
+	s := a.location()
 	if len(a.Fields) > 0 {
 		s += ": " + fmt.Sprintf("%v", a.Fields)
 	}
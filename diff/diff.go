@@ -123,16 +123,67 @@ func compareFields(prev, next rbxdump.Fields) rbxdump.Fields {
 	return fields
 }
 
+// normalizeDefaults returns a copy of the fields of a member, with the
+// default value of the member and of each optional parameter normalized.
+// Values that cannot be normalized are left unchanged.
+func normalizeDefaults(fields rbxdump.Fields) rbxdump.Fields {
+	c := make(rbxdump.Fields, len(fields))
+	for name, value := range fields {
+		c[name] = value
+	}
+	if typ, ok := fields["ValueType"].(rbxdump.Type); ok {
+		if def, ok := fields["Default"].(string); ok {
+			c["Default"], _ = rbxdump.NormalizeDefault(typ, def)
+		}
+	}
+	if params, ok := fields["Parameters"].([]rbxdump.Parameter); ok {
+		params = rbxdump.CopyParams(params)
+		for i, param := range params {
+			if param.Optional {
+				params[i].Default, _ = rbxdump.NormalizeDefault(param.Type, param.Default)
+			}
+		}
+		c["Parameters"] = params
+	}
+	return c
+}
+
+// recordFields returns the fields of f if record is true. Otherwise, returns
+// nil.
+func recordFields(record bool, f rbxdump.Fielder) rbxdump.Fields {
+	if !record {
+		return nil
+	}
+	return f.Fields(nil)
+}
+
+// prevFields returns the values in prev of each field in fields that is
+// present in prev. Returns nil if there are no such fields.
+func prevFields(prev, fields rbxdump.Fields) rbxdump.Fields {
+	var values rbxdump.Fields
+	for name := range fields {
+		if value, ok := prev[name]; ok {
+			if values == nil {
+				values = rbxdump.Fields{}
+			}
+			values[name] = value
+		}
+	}
+	return values
+}
+
 // appendFields appends a template action containing fields. If separate is
 // true, then each field will produce a separate action. Otherwise, all fields
-// will be grouped into one action.
-func appendFields(actions []Action, separate bool, fields rbxdump.Fields, template Action) []Action {
+// will be grouped into one action. Each action records the values in prev of
+// its fields, if any.
+func appendFields(actions []Action, separate bool, fields, prev rbxdump.Fields, template Action) []Action {
 	if len(fields) == 0 {
 		return actions
 	}
 
 	if !separate {
 		template.Fields = fields
+		template.Prev = prevFields(prev, fields)
 		return append(actions, template)
 	}
 
@@ -144,6 +195,7 @@ func appendFields(actions []Action, separate bool, fields rbxdump.Fields, templa
 	for _, name := range names {
 		value := fields[name]
 		template.Fields = rbxdump.Fields{name: value}
+		template.Prev = prevFields(prev, template.Fields)
 		actions = append(actions, template)
 	}
 	return actions
@@ -155,41 +207,146 @@ type Diff struct {
 	// If true, then each change action will have exactly one field. Otherwise,
 	// an action will have all changed fields grouped together.
 	SeparateFields bool
+	// If true, then default values of members are compared after being
+	// normalized with rbxdump.NormalizeDefault, so that differences in
+	// spelling alone are not reported. Changed fields retain the spelling of
+	// the next value.
+	NormalizeDefaults bool
+	// If true, then an element that is removed, and an element of the same
+	// kind that is added, are paired when they are judged to be the same
+	// element under a new name. Each pair is reported as a Rename action that
+	// includes the changed fields of the element, followed by actions that
+	// apply to the contents of the renamed element. Applies to classes,
+	// members, enums, and enum items.
+	//
+	// Elements are paired if the removed element's PreferredDescriptor refers
+	// to the added element, or if the added enum item lists the removed item
+	// among its LegacyNames. Otherwise, elements are paired if each is the
+	// other's unique most similar element, as determined by the fraction of
+	// equal fields, as well as common member or item names for classes and
+	// enums, and common words in the names of members. Members must also have
+	// the same value type or signature, and share at least one word in their
+	// names, and enum items must have the same value.
+	//
+	// Similarity is a heuristic. Unrelated elements that happen to be similar
+	// may be paired, and a renamed element that was also substantially
+	// changed, or a member renamed to an unrelated name, may be reported as a
+	// Remove and an Add. Pairing is reliable only where a PreferredDescriptor
+	// or LegacyNames records the rename.
+	DetectRenames bool
+	// If true, then a member that is removed from one class, and a member of
+	// the same name and member type that is added to another class, are
+	// reported as a Move action, where one class inherits from the other. The
+	// action includes the changed fields of the member. Members are moved only
+	// between classes present in both Prev and Next, and only if the name is
+	// removed from exactly one class and added to exactly one class.
+	//
+	// Move actions are reported after all other class and member actions.
+	DetectMoves bool
+	// If true, then each Change, Rename, and Move action records the previous
+	// values of its fields in Prev, and each Remove action records all fields
+	// of the removed element. A removed class or enum is preceded by Remove
+	// actions for each of its members or items. As a result, the list of
+	// actions can be inverted without the structure to which it applies.
+	RecordPrev bool
 }
 
 // Diff implements the Differ interface.
 func (d Diff) Diff() (actions []Action) {
 	if d.Prev != nil && d.Next != nil {
-		for _, p := range d.Prev.GetClasses() {
+		var classRenames, enumRenames map[string]string
+		if d.DetectRenames {
+			classRenames = renamedClasses(d.Prev, d.Next)
+			enumRenames = renamedEnums(d.Prev, d.Next)
+		}
+		var moved moves
+		if d.DetectMoves {
+			moved = movedMembers(d.Prev, d.Next, classRenames)
+		}
+		movedOut, movedIn := moved.members(true), moved.members(false)
+		prevClasses := map[string]*rbxdump.Class{}
+		for p := range d.Prev.IterClasses() {
+			if name, ok := classRenames[p.Name]; ok {
+				n := d.Next.Classes[name]
+				fields := renameFields(compareFields(p.Fields(nil), n.Fields(nil)))
+				actions = append(actions, Action{
+					Type:    Rename,
+					Element: Class,
+					Primary: p.Name,
+					Target:  name,
+					Fields:  fields,
+					Prev:    prevFields(recordFields(d.RecordPrev, p), fields),
+				})
+				p = renamedClass(p, n)
+			}
 			n := d.Next.Classes[p.Name]
-			actions = append(actions, DiffClass{Prev: p, Next: n, SeparateFields: d.SeparateFields}.Diff()...)
+			actions = append(actions, DiffClass{
+				Prev:              p,
+				Next:              n,
+				SeparateFields:    d.SeparateFields,
+				NormalizeDefaults: d.NormalizeDefaults,
+				DetectRenames:     d.DetectRenames,
+				RecordPrev:        d.RecordPrev,
+				movedOut:          movedOut[p.Name],
+				movedIn:           movedIn[p.Name],
+			}.Diff()...)
+			prevClasses[p.Name] = p
 		}
-		for _, n := range d.Next.GetClasses() {
-			if p := d.Prev.Classes[n.Name]; p == nil {
-				actions = append(actions, DiffClass{Next: n, SeparateFields: d.SeparateFields}.Diff()...)
+		classTargets := renameTargets(classRenames)
+		for n := range d.Next.IterClasses() {
+			if p := d.Prev.Classes[n.Name]; p == nil && !classTargets[n.Name] {
+				actions = append(actions, DiffClass{Next: n, SeparateFields: d.SeparateFields, NormalizeDefaults: d.NormalizeDefaults}.Diff()...)
 			}
 		}
-		for _, p := range d.Prev.GetEnums() {
+		for _, m := range moved {
+			p := prevClasses[m.from].Members[m.member]
+			dm := DiffMember{Prev: p, Next: d.Next.Classes[m.to].Members[m.member], NormalizeDefaults: d.NormalizeDefaults}
+			fields := renameFields(dm.changedFields())
+			actions = append(actions, Action{
+				Type:      Move,
+				Element:   FromElement(p),
+				Primary:   m.from,
+				Secondary: m.member,
+				Target:    m.to,
+				Fields:    fields,
+				Prev:      prevFields(recordFields(d.RecordPrev, p), fields),
+			})
+		}
+		for p := range d.Prev.IterEnums() {
+			if name, ok := enumRenames[p.Name]; ok {
+				n := d.Next.Enums[name]
+				fields := renameFields(compareFields(p.Fields(nil), n.Fields(nil)))
+				actions = append(actions, Action{
+					Type:    Rename,
+					Element: Enum,
+					Primary: p.Name,
+					Target:  name,
+					Fields:  fields,
+					Prev:    prevFields(recordFields(d.RecordPrev, p), fields),
+				})
+				p = renamedEnum(p, n)
+			}
 			n := d.Next.Enums[p.Name]
-			actions = append(actions, DiffEnum{Prev: p, Next: n, SeparateFields: d.SeparateFields}.Diff()...)
+			actions = append(actions, DiffEnum{Prev: p, Next: n, SeparateFields: d.SeparateFields, DetectRenames: d.DetectRenames, RecordPrev: d.RecordPrev}.Diff()...)
 		}
-		for _, n := range d.Next.GetEnums() {
-			if p := d.Prev.Enums[n.Name]; p == nil {
+		enumTargets := renameTargets(enumRenames)
+		for n := range d.Next.IterEnums() {
+			if p := d.Prev.Enums[n.Name]; p == nil && !enumTargets[n.Name] {
 				actions = append(actions, DiffEnum{Next: n, SeparateFields: d.SeparateFields}.Diff()...)
 			}
 		}
 	} else if d.Prev != nil {
-		for _, p := range d.Prev.GetClasses() {
-			actions = append(actions, DiffClass{Prev: p, SeparateFields: d.SeparateFields}.Diff()...)
+		for p := range d.Prev.IterClasses() {
+			actions = append(actions, DiffClass{Prev: p, SeparateFields: d.SeparateFields, NormalizeDefaults: d.NormalizeDefaults, RecordPrev: d.RecordPrev}.Diff()...)
 		}
-		for _, p := range d.Prev.GetEnums() {
-			actions = append(actions, DiffEnum{Prev: p, SeparateFields: d.SeparateFields}.Diff()...)
+		for p := range d.Prev.IterEnums() {
+			actions = append(actions, DiffEnum{Prev: p, SeparateFields: d.SeparateFields, RecordPrev: d.RecordPrev}.Diff()...)
 		}
 	} else if d.Next != nil {
-		for _, n := range d.Next.GetClasses() {
-			actions = append(actions, DiffClass{Next: n, SeparateFields: d.SeparateFields}.Diff()...)
+		for n := range d.Next.IterClasses() {
+			actions = append(actions, DiffClass{Next: n, SeparateFields: d.SeparateFields, NormalizeDefaults: d.NormalizeDefaults}.Diff()...)
 		}
-		for _, n := range d.Next.GetEnums() {
+		for n := range d.Next.IterEnums() {
 			actions = append(actions, DiffEnum{Next: n, SeparateFields: d.SeparateFields}.Diff()...)
 		}
 	}
@@ -205,6 +362,20 @@ type DiffClass struct {
 	// If true, then each change action will have exactly one field. Otherwise,
 	// an action will have all changed fields grouped together.
 	SeparateFields bool
+	// If true, then default values of members are compared after being
+	// normalized with rbxdump.NormalizeDefault, so that differences in
+	// spelling alone are not reported. Changed fields retain the spelling of
+	// the next value.
+	NormalizeDefaults bool
+	// If true, then removed and added members are paired and reported as
+	// Rename actions. See DetectRenames on Diff.
+	DetectRenames bool
+	// If true, then actions record previous values. See RecordPrev on Diff.
+	RecordPrev bool
+
+	// Names of members moved out of and into the class, which are reported
+	// separately by Diff.
+	movedOut, movedIn map[string]bool
 }
 
 // Diff implements the Differ interface.
@@ -223,23 +394,29 @@ func (d DiffClass) Diff() (actions []Action) {
 			Fields:  d.Next.Fields(nil),
 		})
 		if !d.ExcludeMembers {
-			for _, member := range d.Next.GetMembers() {
+			for member := range d.Next.IterMembers() {
 				actions = append(actions, DiffMember{Class: d.Next.Name, Next: member, SeparateFields: d.SeparateFields}.Diff()...)
 			}
 		}
 		return actions
 	} else if d.Next == nil {
+		if d.RecordPrev && !d.ExcludeMembers {
+			for member := range d.Prev.IterMembers() {
+				actions = append(actions, DiffMember{Class: d.Prev.Name, Prev: member, RecordPrev: true}.Diff()...)
+			}
+		}
 		actions = append(actions, Action{
 			Type:    Remove,
 			Element: Class,
 			Primary: d.Prev.Name,
+			Prev:    recordFields(d.RecordPrev, d.Prev),
 		})
 		return actions
 	}
 
 	// Compare and append fields.
 	fields := compareFields(d.Prev.Fields(nil), d.Next.Fields(nil))
-	actions = appendFields(actions, d.SeparateFields, fields, Action{
+	actions = appendFields(actions, d.SeparateFields, fields, recordFields(d.RecordPrev, d.Prev), Action{
 		Type:    Change,
 		Element: Class,
 		Primary: d.Prev.Name,
@@ -249,19 +426,41 @@ func (d DiffClass) Diff() (actions []Action) {
 	if d.ExcludeMembers {
 		return actions
 	}
-	for _, p := range d.Prev.GetMembers() {
+	var renames map[string]string
+	if d.DetectRenames {
+		renames = renamedMembers(d.Prev, d.Next, d.movedOut, d.movedIn)
+	}
+	for p := range d.Prev.IterMembers() {
+		if d.movedOut[p.MemberName()] {
+			continue
+		}
+		if name, ok := renames[p.MemberName()]; ok {
+			dm := DiffMember{Class: d.Prev.Name, Prev: p, Next: d.Next.Members[name], NormalizeDefaults: d.NormalizeDefaults}
+			fields := renameFields(dm.changedFields())
+			actions = append(actions, Action{
+				Type:      Rename,
+				Element:   FromElement(p),
+				Primary:   d.Prev.Name,
+				Secondary: p.MemberName(),
+				Target:    name,
+				Fields:    fields,
+				Prev:      prevFields(recordFields(d.RecordPrev, p), fields),
+			})
+			continue
+		}
 		n := d.Next.Members[p.MemberName()]
 		if compareMemberTypes(p, n) {
-			actions = append(actions, DiffMember{Class: d.Prev.Name, Prev: p, Next: n, SeparateFields: d.SeparateFields}.Diff()...)
+			actions = append(actions, DiffMember{Class: d.Prev.Name, Prev: p, Next: n, SeparateFields: d.SeparateFields, NormalizeDefaults: d.NormalizeDefaults, RecordPrev: d.RecordPrev}.Diff()...)
 			continue
 		}
 		// Member names match, but have different element types. Resolve by
 		// removing the previous and adding the next.
-		actions = append(actions, DiffMember{Class: d.Prev.Name, Prev: p, SeparateFields: d.SeparateFields}.Diff()...)
+		actions = append(actions, DiffMember{Class: d.Prev.Name, Prev: p, SeparateFields: d.SeparateFields, RecordPrev: d.RecordPrev}.Diff()...)
 		actions = append(actions, DiffMember{Class: d.Prev.Name, Next: n, SeparateFields: d.SeparateFields}.Diff()...)
 	}
-	for _, n := range d.Next.GetMembers() {
-		if _, ok := d.Prev.Members[n.MemberName()]; !ok {
+	targets := renameTargets(renames)
+	for n := range d.Next.IterMembers() {
+		if _, ok := d.Prev.Members[n.MemberName()]; !ok && !targets[n.MemberName()] && !d.movedIn[n.MemberName()] {
 			actions = append(actions, DiffMember{Class: d.Prev.Name, Next: n, SeparateFields: d.SeparateFields}.Diff()...)
 		}
 	}
@@ -278,6 +477,13 @@ type DiffMember struct {
 	// If true, then each change action will have exactly one field. Otherwise,
 	// an action will have all changed fields grouped together.
 	SeparateFields bool
+	// If true, then default values of members are compared after being
+	// normalized with rbxdump.NormalizeDefault, so that differences in
+	// spelling alone are not reported. Changed fields retain the spelling of
+	// the next value.
+	NormalizeDefaults bool
+	// If true, then actions record previous values. See RecordPrev on Diff.
+	RecordPrev bool
 }
 
 // Diff implements the Differ interface.
@@ -303,13 +509,13 @@ func (d DiffMember) Diff() (actions []Action) {
 			Element:   FromElement(d.Prev),
 			Primary:   d.Class,
 			Secondary: d.Prev.MemberName(),
+			Prev:      recordFields(d.RecordPrev, d.Prev),
 		})
 		return actions
 	}
 
 	// Compare and append fields.
-	fields := compareFields(d.Prev.Fields(nil), d.Next.Fields(nil))
-	actions = appendFields(actions, d.SeparateFields, fields, Action{
+	actions = appendFields(actions, d.SeparateFields, d.changedFields(), recordFields(d.RecordPrev, d.Prev), Action{
 		Type:      Change,
 		Element:   FromElement(d.Prev),
 		Primary:   d.Class,
@@ -318,6 +524,22 @@ func (d DiffMember) Diff() (actions []Action) {
 	return actions
 }
 
+// changedFields returns the fields that differ between Prev and Next, which
+// must both be non-nil.
+func (d DiffMember) changedFields() rbxdump.Fields {
+	prev, next := d.Prev.Fields(nil), d.Next.Fields(nil)
+	if !d.NormalizeDefaults {
+		return compareFields(prev, next)
+	}
+	fields := compareFields(normalizeDefaults(prev), normalizeDefaults(next))
+	for name, value := range fields {
+		if value != nil {
+			fields[name] = next[name]
+		}
+	}
+	return fields
+}
+
 // DiffEnum is a Differ that finds differences between two rbxdump.Enum
 // values.
 type DiffEnum struct {
@@ -327,6 +549,11 @@ type DiffEnum struct {
 	// If true, then each change action will have exactly one field. Otherwise,
 	// an action will have all changed fields grouped together.
 	SeparateFields bool
+	// If true, then removed and added enum items are paired and reported as
+	// Rename actions. See DetectRenames on Diff.
+	DetectRenames bool
+	// If true, then actions record previous values. See RecordPrev on Diff.
+	RecordPrev bool
 }
 
 // Diff implements the Differ interface.
@@ -345,23 +572,29 @@ func (d DiffEnum) Diff() (actions []Action) {
 			Fields:  d.Next.Fields(nil),
 		})
 		if !d.ExcludeEnumItems {
-			for _, item := range d.Next.GetEnumItems() {
+			for item := range d.Next.IterEnumItems() {
 				actions = append(actions, DiffEnumItem{Enum: d.Next.Name, Next: item, SeparateFields: d.SeparateFields}.Diff()...)
 			}
 		}
 		return actions
 	} else if d.Next == nil {
+		if d.RecordPrev && !d.ExcludeEnumItems {
+			for item := range d.Prev.IterEnumItems() {
+				actions = append(actions, DiffEnumItem{Enum: d.Prev.Name, Prev: item, RecordPrev: true}.Diff()...)
+			}
+		}
 		actions = append(actions, Action{
 			Type:    Remove,
 			Element: Enum,
 			Primary: d.Prev.Name,
+			Prev:    recordFields(d.RecordPrev, d.Prev),
 		})
 		return actions
 	}
 
 	// Compare and append fields.
 	fields := compareFields(d.Prev.Fields(nil), d.Next.Fields(nil))
-	actions = appendFields(actions, d.SeparateFields, fields, Action{
+	actions = appendFields(actions, d.SeparateFields, fields, recordFields(d.RecordPrev, d.Prev), Action{
 		Type:    Change,
 		Element: Enum,
 		Primary: d.Prev.Name,
@@ -371,12 +604,30 @@ func (d DiffEnum) Diff() (actions []Action) {
 	if d.ExcludeEnumItems {
 		return actions
 	}
-	for _, p := range d.Prev.GetEnumItems() {
+	var renames map[string]string
+	if d.DetectRenames {
+		renames = renamedEnumItems(d.Prev, d.Next)
+	}
+	for p := range d.Prev.IterEnumItems() {
+		if name, ok := renames[p.Name]; ok {
+			fields := renameFields(compareFields(p.Fields(nil), d.Next.Items[name].Fields(nil)))
+			actions = append(actions, Action{
+				Type:      Rename,
+				Element:   EnumItem,
+				Primary:   d.Prev.Name,
+				Secondary: p.Name,
+				Target:    name,
+				Fields:    fields,
+				Prev:      prevFields(recordFields(d.RecordPrev, p), fields),
+			})
+			continue
+		}
 		n := d.Next.Items[p.Name]
-		actions = append(actions, DiffEnumItem{Enum: d.Prev.Name, Prev: p, Next: n, SeparateFields: d.SeparateFields}.Diff()...)
+		actions = append(actions, DiffEnumItem{Enum: d.Prev.Name, Prev: p, Next: n, SeparateFields: d.SeparateFields, RecordPrev: d.RecordPrev}.Diff()...)
 	}
-	for _, n := range d.Next.GetEnumItems() {
-		if _, ok := d.Prev.Items[n.Name]; !ok {
+	targets := renameTargets(renames)
+	for n := range d.Next.IterEnumItems() {
+		if _, ok := d.Prev.Items[n.Name]; !ok && !targets[n.Name] {
 			actions = append(actions, DiffEnumItem{Enum: d.Prev.Name, Next: n, SeparateFields: d.SeparateFields}.Diff()...)
 		}
 	}
@@ -393,6 +644,8 @@ type DiffEnumItem struct {
 	// If true, then each change action will have exactly one field. Otherwise,
 	// an action will have all changed fields grouped together.
 	SeparateFields bool
+	// If true, then actions record previous values. See RecordPrev on Diff.
+	RecordPrev bool
 }
 
 // Diff implements the Differ interface.
@@ -418,13 +671,14 @@ func (d DiffEnumItem) Diff() (actions []Action) {
 			Element:   EnumItem,
 			Primary:   d.Enum,
 			Secondary: d.Prev.Name,
+			Prev:      recordFields(d.RecordPrev, d.Prev),
 		})
 		return actions
 	}
 
 	// Compare and append fields.
 	fields := compareFields(d.Prev.Fields(nil), d.Next.Fields(nil))
-	actions = appendFields(actions, d.SeparateFields, fields, Action{
+	actions = appendFields(actions, d.SeparateFields, fields, recordFields(d.RecordPrev, d.Prev), Action{
 		Type:      Change,
 		Element:   EnumItem,
 		Primary:   d.Enum,
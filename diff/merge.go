@@ -0,0 +1,323 @@
+package diff
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/robloxapi/rbxdump"
+)
+
+// ConflictType indicates the kind of incompatibility described by a Conflict.
+type ConflictType int
+
+const (
+	FieldConflict  ConflictType = iota // Both sides set fields of an element to differing values.
+	RemoveConflict                     // One side removed an element that the other changed.
+)
+
+// String returns a string representation of the conflict type.
+func (t ConflictType) String() string {
+	switch t {
+	case FieldConflict:
+		return "FieldConflict"
+	case RemoveConflict:
+		return "RemoveConflict"
+	}
+	return "<invalid>"
+}
+
+// Conflict describes an element that was changed incompatibly by both sides
+// of a Merge.
+type Conflict struct {
+	// Type is the kind of conflict.
+	Type ConflictType
+	// Element is the type of the conflicting element.
+	Element Element
+	// Primary is the name of the primary element.
+	Primary string
+	// Secondary is the name of the secondary element, if any.
+	Secondary string
+	// Fields contains the names of the fields that differ, ordered by name.
+	// Includes "MemberType" if both sides added members of differing types.
+	// Empty for a RemoveConflict.
+	Fields []string
+	// Local and Remote are the actions of each side that apply to the
+	// element. For a RemoveConflict on a class or enum, this includes actions
+	// that apply to members or items of the element.
+	Local, Remote []Action
+}
+
+// sortConflicts sorts Conflict values by the location of the element.
+// Classes and their members are ordered before enums and their items.
+type sortConflicts []Conflict
+
+func (a sortConflicts) Len() int      { return len(a) }
+func (a sortConflicts) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a sortConflicts) Less(i, j int) bool {
+	if p, q := a[i].Element.Primary(), a[j].Element.Primary(); p != q {
+		return p < q
+	}
+	if a[i].Primary != a[j].Primary {
+		return a[i].Primary < a[j].Primary
+	}
+	if a[i].Secondary != a[j].Secondary {
+		return a[i].Secondary < a[j].Secondary
+	}
+	return a[i].Element < a[j].Element
+}
+
+// Merge performs a three-way merge of two lists of actions that each apply to
+// a common base.
+type Merge struct {
+	// Base is the structure to which both lists of actions apply. A nil Base
+	// is treated as empty.
+	Base *rbxdump.Root
+	// Local and Remote are the lists of actions to be merged.
+	Local, Remote []Action
+}
+
+// NewMerge returns a Merge of the differences between base and each of two
+// structures derived from it.
+func NewMerge(base, local, remote *rbxdump.Root) Merge {
+	return Merge{
+		Base:   base,
+		Local:  Diff{Prev: base, Next: local}.Diff(),
+		Remote: Diff{Prev: base, Next: remote}.Diff(),
+	}
+}
+
+// mergeKey locates an element within a Merge. Members of differing types
+// with the same name share a key.
+type mergeKey struct {
+	outer              Element
+	primary, secondary string
+}
+
+func keyOf(action Action) mergeKey {
+	return mergeKey{outer: action.Element.Primary(), primary: action.Primary, secondary: action.Secondary}
+}
+
+// effect summarizes the actions of one side that apply to an element.
+type effect struct {
+	remove      bool
+	add, change *Action
+}
+
+func effectOf(actions []Action) (e effect) {
+	for i, action := range actions {
+		switch action.Type {
+		case Remove:
+			e.remove = true
+		case Add:
+			e.add = &actions[i]
+		case Change:
+			e.change = &actions[i]
+		}
+	}
+	return e
+}
+
+// removesOnly returns whether the actions remove an element without replacing
+// it.
+func (e effect) removesOnly() bool {
+	return e.remove && e.add == nil
+}
+
+// fieldsEqual returns whether two sets of fields are equal.
+func fieldsEqual(a, b rbxdump.Fields) bool {
+	return len(compareFields(a, b)) == 0 && len(compareFields(b, a)) == 0
+}
+
+// actionsEqual returns whether two lists of actions are equal.
+func actionsEqual(a, b []Action) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || a[i].Element != b[i].Element || !fieldsEqual(a[i].Fields, b[i].Fields) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeFields returns the union of two sets of fields, excluding fields that
+// are in both sets with differing values. The names of the excluded fields are
+// also returned, ordered by name.
+func mergeFields(local, remote rbxdump.Fields) (fields rbxdump.Fields, conflicts []string) {
+	fields = make(rbxdump.Fields, len(local)+len(remote))
+	for name, value := range local {
+		fields[name] = value
+	}
+	for name, value := range remote {
+		if l, ok := local[name]; ok {
+			if !fieldsEqual(rbxdump.Fields{name: l}, rbxdump.Fields{name: value}) {
+				delete(fields, name)
+				conflicts = append(conflicts, name)
+			}
+			continue
+		}
+		fields[name] = value
+	}
+	sort.Strings(conflicts)
+	return fields, conflicts
+}
+
+// canonical returns the actions that transform base in the same way as the
+// given actions, as produced by Diff.
+func canonical(base *rbxdump.Root, actions []Action) []Action {
+	patch := Patch{base.Copy()}
+	patch.Patch(actions)
+	return Diff{Prev: base, Next: patch.Root}.Diff()
+}
+
+// group maps the key of each action to the actions with that key.
+func group(actions []Action) map[mergeKey][]Action {
+	groups := map[mergeKey][]Action{}
+	for _, action := range actions {
+		key := keyOf(action)
+		groups[key] = append(groups[key], action)
+	}
+	return groups
+}
+
+// contents returns the actions that apply to an outer element or to any of
+// its members or items.
+func contents(actions []Action, key mergeKey) (list []Action) {
+	for _, action := range actions {
+		if k := keyOf(action); k.outer == key.outer && k.primary == key.primary {
+			list = append(list, action)
+		}
+	}
+	return list
+}
+
+// mergeElement merges the actions of each side that apply to an element.
+// Returns the merged actions, and a conflict if one occurred.
+func mergeElement(local, remote []Action) ([]Action, *Conflict) {
+	if len(local) == 0 || actionsEqual(local, remote) {
+		return remote, nil
+	}
+	if len(remote) == 0 {
+		return local, nil
+	}
+	l, r := effectOf(local), effectOf(remote)
+	switch {
+	case l.change != nil && r.change != nil:
+		fields, names := mergeFields(l.change.Fields, r.change.Fields)
+		change := *l.change
+		change.Fields = fields
+		var merged []Action
+		if len(fields) > 0 {
+			merged = []Action{change}
+		}
+		if len(names) == 0 {
+			return merged, nil
+		}
+		return merged, &Conflict{Type: FieldConflict, Fields: names}
+	case l.add != nil && r.add != nil && l.remove == r.remove:
+		var names []string
+		if l.add.Element != r.add.Element {
+			names = []string{"MemberType"}
+		} else {
+			for name := range compareFields(l.add.Fields, r.add.Fields) {
+				names = append(names, name)
+			}
+			for name := range compareFields(r.add.Fields, l.add.Fields) {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			names = slices.Compact(names)
+		}
+		return nil, &Conflict{Type: FieldConflict, Fields: names}
+	case l.remove && r.remove:
+		// Both sides remove the element, and one side replaces it.
+		if l.add != nil {
+			return local, nil
+		}
+		return remote, nil
+	}
+	return nil, &Conflict{Type: RemoveConflict}
+}
+
+// Merge returns a list of actions that applies the changes of both Local and
+// Remote to Base, along with the conflicts between them, ordered by location.
+//
+// Each list is first reduced to the differences between Base and the result
+// of applying the list to Base, as produced by Diff. As a result, a renamed
+// or moved element is treated as a removed element and an added element. An
+// element changed by only one side receives that side's changes. Changes made
+// by both sides to differing fields of an element are combined.
+//
+// A FieldConflict occurs when both sides set the same field of an element to
+// differing values, in which case the field is left unchanged, or when both
+// sides add an element with differing fields, in which case the element and
+// its contents are not added. A RemoveConflict occurs when one side removes an
+// element that the other side changes, or, for a class or enum, when the
+// other side changes any of its members or items. In this case, the element
+// and its contents are left unchanged.
+func (m Merge) Merge() (actions []Action, conflicts []Conflict) {
+	base := m.Base
+	if base == nil {
+		base = &rbxdump.Root{}
+	}
+	localActions := canonical(base, m.Local)
+	remoteActions := canonical(base, m.Remote)
+	local, remote := group(localActions), group(remoteActions)
+
+	// Find classes and enums removed by one side, where the other side
+	// changes the element or its contents.
+	dropped := map[mergeKey]bool{}
+	for _, list := range [][]Action{localActions, remoteActions} {
+		for _, action := range list {
+			key := keyOf(action)
+			if key.secondary != "" || dropped[key] {
+				continue
+			}
+			if effectOf(local[key]).removesOnly() == effectOf(remote[key]).removesOnly() {
+				continue
+			}
+			l, r := contents(localActions, key), contents(remoteActions, key)
+			if len(l) == 0 || len(r) == 0 {
+				continue
+			}
+			dropped[key] = true
+			conflicts = append(conflicts, Conflict{
+				Type:    RemoveConflict,
+				Element: key.outer,
+				Primary: key.primary,
+				Local:   l,
+				Remote:  r,
+			})
+		}
+	}
+
+	// Merge each element, in the order in which it first appears.
+	merged := map[mergeKey]bool{}
+	for _, list := range [][]Action{localActions, remoteActions} {
+		for _, action := range list {
+			key := keyOf(action)
+			if merged[key] || dropped[mergeKey{outer: key.outer, primary: key.primary}] {
+				continue
+			}
+			merged[key] = true
+			result, conflict := mergeElement(local[key], remote[key])
+			actions = append(actions, result...)
+			if conflict == nil {
+				continue
+			}
+			conflict.Element = action.Element
+			conflict.Primary = key.primary
+			conflict.Secondary = key.secondary
+			conflict.Local = local[key]
+			conflict.Remote = remote[key]
+			conflicts = append(conflicts, *conflict)
+			if key.secondary == "" && effectOf(local[key]).add != nil {
+				// The element is not added, so neither are its contents.
+				dropped[key] = true
+			}
+		}
+	}
+	sort.Sort(sortConflicts(conflicts))
+	return actions, conflicts
+}
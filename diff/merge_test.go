@@ -0,0 +1,140 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/robloxapi/rbxdump"
+)
+
+func mergeBase() *rbxdump.Root {
+	return &rbxdump.Root{
+		Classes: map[string]*rbxdump.Class{
+			"Model": {Name: "Model", Members: map[string]rbxdump.Member{}},
+			"Part": {Name: "Part", Members: map[string]rbxdump.Member{
+				"Size": &rbxdump.Property{Name: "Size", Category: "Data"},
+			}},
+		},
+		Enums: map[string]*rbxdump.Enum{},
+	}
+}
+
+func TestMerge(t *testing.T) {
+	removePart := Action{Type: Remove, Element: Class, Primary: "Part"}
+	changeSize := func(fields rbxdump.Fields) Action {
+		return Action{Type: Change, Element: Property, Primary: "Part", Secondary: "Size", Fields: fields}
+	}
+	addMember := func(class string, element Element, fields rbxdump.Fields) Action {
+		return Action{Type: Add, Element: element, Primary: class, Secondary: "Color", Fields: fields}
+	}
+	addTool := func(tags rbxdump.Tags) Action {
+		return Action{Type: Add, Element: Class, Primary: "Tool", Fields: rbxdump.Fields{"Tags": tags}}
+	}
+	tests := []struct {
+		name          string
+		local, remote []Action
+		// Locations of the merged actions.
+		actions []string
+		// Type, location, and fields of each conflict.
+		conflicts []string
+	}{
+		{
+			name:   "Disjoint",
+			local:  []Action{changeSize(rbxdump.Fields{"Category": "Appearance"})},
+			remote: []Action{addMember("Model", Property, nil)},
+			actions: []string{
+				"Change Property Part.Size: map[Category:Appearance]",
+				"Add Property Model.Color",
+			},
+		},
+		{
+			name:    "DifferingFields",
+			local:   []Action{changeSize(rbxdump.Fields{"Category": "Appearance"})},
+			remote:  []Action{changeSize(rbxdump.Fields{"Default": "1, 1, 1"})},
+			actions: []string{"Change Property Part.Size: map[Category:Appearance Default:1, 1, 1]"},
+		},
+		{
+			name:      "SameField",
+			local:     []Action{changeSize(rbxdump.Fields{"Category": "Appearance", "Default": "1, 1, 1"})},
+			remote:    []Action{changeSize(rbxdump.Fields{"Category": "Behavior", "Default": "1, 1, 1"})},
+			actions:   []string{"Change Property Part.Size: map[Default:1, 1, 1]"},
+			conflicts: []string{"FieldConflict Property Part.Size [Category]"},
+		},
+		{
+			name:   "RemovedClassChangedMember",
+			local:  []Action{removePart},
+			remote: []Action{changeSize(rbxdump.Fields{"Category": "Appearance"}), addMember("Model", Property, nil)},
+			// The class and its contents are left unchanged, while other
+			// elements are merged.
+			actions:   []string{"Add Property Model.Color"},
+			conflicts: []string{"RemoveConflict Class Part []"},
+		},
+		{
+			name:      "ChangedMemberRemovedClass",
+			local:     []Action{changeSize(rbxdump.Fields{"Category": "Appearance"})},
+			remote:    []Action{removePart},
+			conflicts: []string{"RemoveConflict Class Part []"},
+		},
+		{
+			name:   "RemovedClassRemovedMember",
+			local:  []Action{removePart},
+			remote: []Action{{Type: Remove, Element: Property, Primary: "Part", Secondary: "Size"}},
+			// Removing a member also changes the contents of the class, so
+			// the class is not removed.
+			conflicts: []string{"RemoveConflict Class Part []"},
+		},
+		{
+			name:    "BothRemoved",
+			local:   []Action{removePart},
+			remote:  []Action{removePart},
+			actions: []string{"Remove Class Part"},
+		},
+		{
+			name:    "AddedSame",
+			local:   []Action{addTool(rbxdump.Tags{"Deprecated"})},
+			remote:  []Action{addTool(rbxdump.Tags{"Deprecated"})},
+			actions: []string{"Add Class Tool"},
+		},
+		{
+			name:   "AddedDiffering",
+			local:  []Action{addTool(rbxdump.Tags{"Deprecated"}), addMember("Tool", Property, nil)},
+			remote: []Action{addTool(nil), addMember("Tool", Property, nil)},
+			// The class is not added, so neither are its members.
+			conflicts: []string{"FieldConflict Class Tool [Tags]"},
+		},
+		{
+			name:      "AddedDifferingTypes",
+			local:     []Action{addMember("Model", Property, nil)},
+			remote:    []Action{addMember("Model", Function, nil)},
+			conflicts: []string{"FieldConflict Property Model.Color [MemberType]"},
+		},
+	}
+	for _, test := range tests {
+		actions, conflicts := Merge{Base: mergeBase(), Local: test.local, Remote: test.remote}.Merge()
+		var gotActions []string
+		for _, action := range actions {
+			if action.Type == Change {
+				gotActions = append(gotActions, action.String())
+			} else {
+				gotActions = append(gotActions, action.location())
+			}
+		}
+		if !reflect.DeepEqual(gotActions, test.actions) {
+			t.Errorf("%s: got actions %q, want %q", test.name, gotActions, test.actions)
+		}
+		var gotConflicts []string
+		for _, c := range conflicts {
+			location := Action{Element: c.Element, Primary: c.Primary, Secondary: c.Secondary}.location()
+			location = c.Type.String() + strings.TrimPrefix(location, Change.String())
+			gotConflicts = append(gotConflicts, fmt.Sprintf("%s %v", location, c.Fields))
+			if len(c.Local) == 0 || len(c.Remote) == 0 {
+				t.Errorf("%s: conflict %s lacks the actions of each side", test.name, location)
+			}
+		}
+		if !reflect.DeepEqual(gotConflicts, test.conflicts) {
+			t.Errorf("%s: got conflicts %q, want %q", test.name, gotConflicts, test.conflicts)
+		}
+	}
+}
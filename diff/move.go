@@ -0,0 +1,104 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/robloxapi/rbxdump"
+)
+
+// move describes a member that moved from one class to another.
+type move struct {
+	from, to, member string
+}
+
+// moves is a list of moved members.
+type moves []move
+
+// members returns, for each class, the set of members moved out of the class
+// if out is true, or moved into the class otherwise.
+func (m moves) members(out bool) map[string]map[string]bool {
+	sets := map[string]map[string]bool{}
+	for _, mv := range m {
+		class := mv.to
+		if out {
+			class = mv.from
+		}
+		if sets[class] == nil {
+			sets[class] = map[string]bool{}
+		}
+		sets[class][mv.member] = true
+	}
+	return sets
+}
+
+// Len implements sort.Interface.
+func (m moves) Len() int { return len(m) }
+
+// Less implements sort.Interface. Moves are ordered by source class, then
+// member name.
+func (m moves) Less(i, j int) bool {
+	if m[i].from != m[j].from {
+		return m[i].from < m[j].from
+	}
+	return m[i].member < m[j].member
+}
+
+// Swap implements sort.Interface.
+func (m moves) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+
+// movedMembers finds members that were removed from one class and added to
+// another, where one class inherits from the other in next. Only classes
+// present in both prev and next are considered. A member is moved only if
+// exactly one class lost a member of the name, and exactly one class gained a
+// member of the name, with the same member type.
+//
+// Classes are identified by their names in next. classRenames maps the names
+// of renamed classes in prev to their names in next.
+func movedMembers(prev, next *rbxdump.Root, classRenames map[string]string) moves {
+	prevNames := make(map[string]string, len(classRenames))
+	for p, n := range classRenames {
+		prevNames[n] = p
+	}
+	removed := map[string][]string{}
+	added := map[string][]string{}
+	members := map[string]rbxdump.Member{}
+	for n := range next.IterClasses() {
+		name, ok := prevNames[n.Name]
+		if !ok {
+			name = n.Name
+		}
+		p := prev.Classes[name]
+		if p == nil {
+			continue
+		}
+		for member := range p.IterMembers() {
+			if _, ok := n.Members[member.MemberName()]; !ok {
+				removed[member.MemberName()] = append(removed[member.MemberName()], n.Name)
+				members[member.MemberName()] = member
+			}
+		}
+		for member := range n.IterMembers() {
+			if _, ok := p.Members[member.MemberName()]; !ok {
+				added[member.MemberName()] = append(added[member.MemberName()], n.Name)
+			}
+		}
+	}
+
+	h := rbxdump.NewHierarchy(next)
+	var list moves
+	for member, from := range removed {
+		to := added[member]
+		if len(from) != 1 || len(to) != 1 {
+			continue
+		}
+		if !h.IsA(from[0], to[0]) && !h.IsA(to[0], from[0]) {
+			continue
+		}
+		if !compareMemberTypes(members[member], next.Classes[to[0]].Members[member]) {
+			continue
+		}
+		list = append(list, move{from: from[0], to: to[0], member: member})
+	}
+	sort.Sort(list)
+	return list
+}
@@ -2,6 +2,7 @@ package diff
 
 import (
 	"maps"
+	"slices"
 
 	"github.com/robloxapi/rbxdump"
 )
@@ -14,6 +15,17 @@ type Patch struct {
 
 // Patch implements the Patcher interface.
 func (root *Patch) Patch(actions []Action) {
+	root.patch(actions, patchErrors{})
+}
+
+// PatchChecked implements the CheckedPatcher interface.
+func (root *Patch) PatchChecked(actions []Action) error {
+	errs := newPatchErrors()
+	root.patch(actions, errs)
+	return errs.err()
+}
+
+func (root *Patch) patch(actions []Action, errs patchErrors) {
 	if root.Root == nil {
 		root.Root = &rbxdump.Root{}
 	}
@@ -23,136 +35,364 @@ func (root *Patch) Patch(actions []Action) {
 			switch action.Type {
 			case Add:
 				if class := root.Classes[action.Primary]; class != nil {
-					class.SetFields(action.Fields)
+					errs.setFields(i, action, class)
 				} else {
 					class := rbxdump.Class{Name: action.Primary}
-					class.SetFields(action.Fields)
+					errs.setFields(i, action, &class)
 					if root.Classes == nil {
 						root.Classes = map[string]*rbxdump.Class{}
 					}
 					root.Classes[action.Primary] = &class
+					root.ClassOrder = appendOrder(root.ClassOrder, action.Primary)
 				}
 			case Remove:
 				delete(root.Classes, action.Primary)
+				root.ClassOrder = removeOrder(root.ClassOrder, action.Primary)
 			case Change:
 				if class := root.Classes[action.Primary]; class != nil {
-					class.SetFields(action.Fields)
+					errs.setFields(i, action, class)
+				}
+			case Rename:
+				if class := root.Classes[action.Primary]; class != nil && action.Target != "" && root.Classes[action.Target] == nil {
+					delete(root.Classes, action.Primary)
+					class.Name = action.Target
+					root.Classes[action.Target] = class
+					root.ClassOrder = renameOrder(root.ClassOrder, action.Primary, action.Target)
+					errs.setFields(i, action, class)
 				}
 			}
 		case Property, Function, Event, Callback:
-			if class := root.Classes[action.Primary]; class != nil {
-				(&PatchClass{class}).Patch(actions[i : i+1])
+			if action.Type == Move {
+				root.moveMember(action, errs.sub(i))
+			} else if class := root.Classes[action.Primary]; class != nil {
+				(&PatchClass{class}).patch(actions[i:i+1], errs.sub(i))
 			}
 		case Enum:
 			switch action.Type {
 			case Add:
 				if enum := root.Enums[action.Primary]; enum != nil {
-					enum.SetFields(action.Fields)
+					errs.setFields(i, action, enum)
 				} else {
 					enum := rbxdump.Enum{Name: action.Primary}
-					enum.SetFields(action.Fields)
+					errs.setFields(i, action, &enum)
 					if root.Enums == nil {
 						root.Enums = map[string]*rbxdump.Enum{}
 					}
 					root.Enums[action.Primary] = &enum
+					root.EnumOrder = appendOrder(root.EnumOrder, action.Primary)
 				}
 			case Remove:
 				delete(root.Enums, action.Primary)
+				root.EnumOrder = removeOrder(root.EnumOrder, action.Primary)
 			case Change:
 				if enum := root.Enums[action.Primary]; enum != nil {
-					enum.SetFields(action.Fields)
+					errs.setFields(i, action, enum)
+				}
+			case Rename:
+				if enum := root.Enums[action.Primary]; enum != nil && action.Target != "" && root.Enums[action.Target] == nil {
+					delete(root.Enums, action.Primary)
+					enum.Name = action.Target
+					root.Enums[action.Target] = enum
+					root.EnumOrder = renameOrder(root.EnumOrder, action.Primary, action.Target)
+					errs.setFields(i, action, enum)
 				}
 			}
 		case EnumItem:
 			if enum := root.Enums[action.Primary]; enum != nil {
-				(&PatchEnum{enum}).Patch(actions[i : i+1])
+				(&PatchEnum{enum}).patch(actions[i:i+1], errs.sub(i))
 			}
 		}
 	}
 }
 
+// moveMember applies a Move action to a member of the root. The member is
+// moved only if both classes exist, the member type matches, and the target
+// class has no member of the same name.
+func (root *Patch) moveMember(action Action, errs patchErrors) {
+	from, to := root.Classes[action.Primary], root.Classes[action.Target]
+	if from == nil || to == nil || from == to {
+		return
+	}
+	member := from.Members[action.Secondary]
+	if member == nil || FromElement(member) != action.Element {
+		return
+	}
+	if _, ok := to.Members[action.Secondary]; ok {
+		return
+	}
+	delete(from.Members, action.Secondary)
+	from.MemberOrder = removeOrder(from.MemberOrder, action.Secondary)
+	if to.Members == nil {
+		to.Members = map[string]rbxdump.Member{}
+	}
+	to.Members[action.Secondary] = member
+	to.MemberOrder = appendOrder(to.MemberOrder, action.Secondary)
+	errs.setFields(0, action, member)
+}
+
+// patchErrors collects errors produced while applying a list of actions. The
+// zero value discards errors.
+type patchErrors struct {
+	list *PatchError
+	// Index of the first action of the current list within the list
+	// originally received.
+	base int
+}
+
+// newPatchErrors returns a patchErrors that collects errors.
+func newPatchErrors() patchErrors {
+	return patchErrors{list: &PatchError{}}
+}
+
+// sub returns a patchErrors for a sub-list of actions starting at index i.
+func (e patchErrors) sub(i int) patchErrors {
+	e.base += i
+	return e
+}
+
+// setFields sets the fields of action i on element f, recording an error if
+// errors are being collected and the fields could not be set.
+func (e patchErrors) setFields(i int, action Action, f rbxdump.Fielder) {
+	if e.list == nil {
+		f.SetFields(action.Fields)
+		return
+	}
+	if err := rbxdump.SetFields(f, action.Fields); err != nil {
+		*e.list = append(*e.list, ActionError{Index: e.base + i, Action: action, Err: err})
+	}
+}
+
+// err returns the collected errors as a PatchError, or nil if there are none.
+func (e patchErrors) err() error {
+	if e.list == nil || len(*e.list) == 0 {
+		return nil
+	}
+	return *e.list
+}
+
+// appendOrder appends name to an element order if the order is present and
+// does not already contain name.
+func appendOrder(order []string, name string) []string {
+	if order == nil || slices.Contains(order, name) {
+		return order
+	}
+	return append(order, name)
+}
+
+// renameOrder replaces name from with name to within an element order.
+func renameOrder(order []string, from, to string) []string {
+	if i := slices.Index(order, from); i >= 0 {
+		order[i] = to
+	}
+	return order
+}
+
+// removeOrder removes name from an element order.
+func removeOrder(order []string, name string) []string {
+	if order == nil {
+		return nil
+	}
+	return slices.DeleteFunc(order, func(s string) bool { return s == name })
+}
+
 // Inverse implements the Inverter interface by producing the inverse of actions
-// according to the root.
+// according to the root, which is the structure to which the actions apply.
+// Actions are inverted in reverse order, so that an action that depends on an
+// earlier action, such as one that applies to a renamed element, is undone
+// first.
+//
+// Previous values recorded in the Prev field of an action take precedence
+// over the root. If every action records its previous values, as produced by
+// Diff with RecordPrev, then the root is not needed, and may be nil. Each
+// inverted action records the values of its fields before it is applied, as
+// far as they are known.
 func (root Patch) Inverse(actions []Action) []Action {
 	reversed := make([]Action, len(actions))
+	var names renames
 	for i, action := range actions {
 		rev := action
 		rev.Type = -rev.Type
-		rev.Fields = maps.Clone(rev.Fields)
-		switch rev.Type {
-		case Remove:
-			rev.Fields = nil
-			goto finish
-		case Change:
-			if root.Root != nil {
-				switch rev.Element {
-				case Class:
-					if class, ok := root.Classes[rev.Primary]; ok {
-						rev.Fields = class.Fields(rev.Fields)
-						goto finish
-					}
-				case Property, Function, Event, Callback:
-					if class, ok := root.Classes[rev.Primary]; ok {
-						if member, ok := class.Members[rev.Secondary]; ok {
-							rev.Fields = member.Fields(rev.Fields)
-							goto finish
-						}
-					}
-				case Enum:
-					if enum, ok := root.Enums[rev.Primary]; ok {
-						rev.Fields = enum.Fields(rev.Fields)
-						goto finish
-					}
-				case EnumItem:
-					if enum, ok := root.Enums[rev.Primary]; ok {
-						if item, ok := enum.Items[rev.Secondary]; ok {
-							rev.Fields = item.Fields(rev.Fields)
-							goto finish
-						}
-					}
-				}
-			}
+		rev.Fields = nil
+		rev.Prev = nil
+		// Locate the element within the root.
+		primary, secondary := names.original(action)
+		element := root.element(action.Element, primary, secondary)
+		switch action.Type {
 		case Add:
-			if root.Root != nil {
-				switch rev.Element {
-				case Class:
-					if class, ok := root.Classes[rev.Primary]; ok {
-						rev.Fields = class.Fields(rev.Fields)
-						goto finish
-					}
-				case Property, Function, Event, Callback:
-					if class, ok := root.Classes[rev.Primary]; ok {
-						if member, ok := class.Members[rev.Secondary]; ok {
-							rev.Fields = member.Fields(rev.Fields)
-							goto finish
-						}
-					}
-				case Enum:
-					if enum, ok := root.Enums[rev.Primary]; ok {
-						rev.Fields = enum.Fields(rev.Fields)
-						goto finish
-					}
-				case EnumItem:
-					if enum, ok := root.Enums[rev.Primary]; ok {
-						if item, ok := enum.Items[rev.Secondary]; ok {
-							rev.Fields = item.Fields(rev.Fields)
-							goto finish
-						}
-					}
-				}
+			rev.Prev = maps.Clone(action.Fields)
+		case Change, Remove:
+			rev.Fields = previous(action, element)
+			if action.Type == Change {
+				rev.Prev = maps.Clone(action.Fields)
 			}
+		case Rename:
+			rev.Type = Rename
+			if action.Element == Class || action.Element == Enum {
+				rev.Primary, rev.Target = action.Target, action.Primary
+			} else {
+				rev.Secondary, rev.Target = action.Target, action.Secondary
+			}
+			if action.Fields != nil {
+				rev.Fields = previous(action, element)
+				rev.Prev = maps.Clone(action.Fields)
+			}
+			names.rename(action, primary, secondary)
+		case Move:
+			rev.Type = Move
+			rev.Primary, rev.Target = action.Target, action.Primary
+			if action.Fields != nil {
+				rev.Fields = previous(action, element)
+				rev.Prev = maps.Clone(action.Fields)
+			}
+			names.move(action, primary, secondary)
 		}
-		if fielder := action.ToFielder(); fielder != nil {
-			rev.Fields = fielder.Fields(rev.Fields)
-		} else {
-			rev.Fields = rbxdump.Fields{}
-		}
-	finish:
-		reversed[i] = rev
+		reversed[len(actions)-1-i] = rev
 	}
 	return reversed
 }
 
+// previous returns the values of the fields of an action before the action is
+// applied. For a Remove action, this includes all fields of the element.
+// Values are taken from the Prev field of the action, then from element, if
+// not nil. Otherwise, the zero values of the fields are used.
+func previous(action Action, element rbxdump.Fielder) rbxdump.Fields {
+	if action.Type == Remove && action.Prev != nil {
+		return maps.Clone(action.Prev)
+	}
+	fields := maps.Clone(action.Fields)
+	if element != nil {
+		fields = element.Fields(fields)
+	} else if fielder := action.ToFielder(); fielder != nil {
+		fields = fielder.Fields(fields)
+	} else {
+		return rbxdump.Fields{}
+	}
+	for name, value := range action.Prev {
+		if _, ok := fields[name]; ok {
+			fields[name] = value
+		}
+	}
+	return fields
+}
+
+// element returns the element of the root that is located by the given
+// names. Returns nil if the element does not exist.
+func (root Patch) element(e Element, primary, secondary string) rbxdump.Fielder {
+	if root.Root == nil {
+		return nil
+	}
+	switch e {
+	case Class:
+		if class := root.Classes[primary]; class != nil {
+			return class
+		}
+	case Property, Function, Event, Callback:
+		if class := root.Classes[primary]; class != nil {
+			if member := class.Members[secondary]; member != nil {
+				return member
+			}
+		}
+	case Enum:
+		if enum := root.Enums[primary]; enum != nil {
+			return enum
+		}
+	case EnumItem:
+		if enum := root.Enums[primary]; enum != nil {
+			if item := enum.Items[secondary]; item != nil {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+// renames tracks the original names of elements renamed or moved by a list of
+// actions.
+type renames struct {
+	// Current name of each renamed class or enum, mapped to its original name.
+	classes, enums map[string]string
+	// Original name of the outer element and current name of each renamed
+	// member or item, mapped to its original name.
+	members, items map[[2]string]string
+	// Original name of the class and original name of each moved member,
+	// mapped to the original name of the class from which it was moved.
+	moved map[[2]string]string
+}
+
+// outer returns the original name of the outer element of the element to
+// which action applies.
+func (r *renames) outer(action Action) string {
+	var outer map[string]string
+	switch {
+	case action.Element == Class, action.Element.IsMember():
+		outer = r.classes
+	case action.Element == Enum, action.Element == EnumItem:
+		outer = r.enums
+	}
+	if name, ok := outer[action.Primary]; ok {
+		return name
+	}
+	return action.Primary
+}
+
+// original returns the names of the element to which action applies, as they
+// were before any renames or moves.
+func (r *renames) original(action Action) (primary, secondary string) {
+	primary, secondary = r.outer(action), action.Secondary
+	var inner map[[2]string]string
+	switch {
+	case action.Element.IsMember():
+		inner = r.members
+	case action.Element == EnumItem:
+		inner = r.items
+	}
+	if name, ok := inner[[2]string{primary, secondary}]; ok {
+		secondary = name
+	}
+	if action.Element.IsMember() {
+		if name, ok := r.moved[[2]string{primary, secondary}]; ok {
+			primary = name
+		}
+	}
+	return primary, secondary
+}
+
+// rename records a Rename action, given the original names of the element.
+func (r *renames) rename(action Action, primary, secondary string) {
+	switch {
+	case action.Element == Class:
+		r.classes = renameName(r.classes, action.Primary, action.Target, primary)
+	case action.Element.IsMember():
+		outer := r.outer(action)
+		r.members = renameName(r.members, [2]string{outer, action.Secondary}, [2]string{outer, action.Target}, secondary)
+	case action.Element == Enum:
+		r.enums = renameName(r.enums, action.Primary, action.Target, primary)
+	case action.Element == EnumItem:
+		outer := r.outer(action)
+		r.items = renameName(r.items, [2]string{outer, action.Secondary}, [2]string{outer, action.Target}, secondary)
+	}
+}
+
+// move records a Move action, given the original names of the member.
+func (r *renames) move(action Action, primary, secondary string) {
+	from := r.outer(action)
+	to := r.outer(Action{Element: action.Element, Primary: action.Target})
+	if secondary != action.Secondary {
+		r.members = renameName(r.members, [2]string{from, action.Secondary}, [2]string{to, action.Secondary}, secondary)
+	}
+	r.moved = renameName(r.moved, [2]string{from, secondary}, [2]string{to, secondary}, primary)
+}
+
+// renameName maps the key to to the original name, replacing from.
+func renameName[K comparable](m map[K]string, from, to K, original string) map[K]string {
+	if m == nil {
+		m = map[K]string{}
+	}
+	delete(m, from)
+	m[to] = original
+	return m
+}
+
 // PatchClass is used to transform the embedded rbxdump.Class by applying a list
 // of Actions.
 type PatchClass struct {
@@ -161,53 +401,96 @@ type PatchClass struct {
 
 // Patch implements the Patcher interface.
 func (class *PatchClass) Patch(actions []Action) {
+	class.patch(actions, patchErrors{})
+}
+
+// PatchChecked implements the CheckedPatcher interface.
+func (class *PatchClass) PatchChecked(actions []Action) error {
+	errs := newPatchErrors()
+	class.patch(actions, errs)
+	return errs.err()
+}
+
+func (class *PatchClass) patch(actions []Action, errs patchErrors) {
 	if class.Class == nil {
 		class.Class = &rbxdump.Class{}
 	}
-	for _, action := range actions {
+	for i, action := range actions {
 		switch action.Element {
 		case Class:
-			if action.Type == Change {
-				class.SetFields(action.Fields)
+			switch action.Type {
+			case Change:
+				errs.setFields(i, action, class.Class)
+			case Rename:
+				class.Name = action.Target
+				errs.setFields(i, action, class.Class)
 			}
 		case Property:
-			patchMember[*rbxdump.Property](class, action)
+			patchMember[*rbxdump.Property](class, action, errs.sub(i))
 		case Function:
-			patchMember[*rbxdump.Function](class, action)
+			patchMember[*rbxdump.Function](class, action, errs.sub(i))
 		case Event:
-			patchMember[*rbxdump.Event](class, action)
+			patchMember[*rbxdump.Event](class, action, errs.sub(i))
 		case Callback:
-			patchMember[*rbxdump.Callback](class, action)
+			patchMember[*rbxdump.Callback](class, action, errs.sub(i))
 		}
 	}
 }
 
-func patchMember[T rbxdump.Member](class *PatchClass, action Action) {
+func patchMember[T rbxdump.Member](class *PatchClass, action Action, errs patchErrors) {
 	switch action.Type {
 	case Add:
 		if member, ok := class.Members[action.Secondary].(T); ok {
 			// Change matching type.
-			member.SetFields(action.Fields)
+			errs.setFields(0, action, member)
 			return
 		}
 		// Add new member or overwrite member of non-matching type.
 		if member := action.ToMember(); member != nil {
-			member.SetFields(action.Fields)
+			errs.setFields(0, action, member)
 			if class.Members == nil {
 				class.Members = map[string]rbxdump.Member{}
 			}
 			class.Members[action.Secondary] = member
+			class.MemberOrder = appendOrder(class.MemberOrder, action.Secondary)
 		}
 	case Remove:
 		if _, ok := class.Members[action.Secondary].(T); ok {
 			// Remove only if type matches.
 			delete(class.Members, action.Secondary)
+			class.MemberOrder = removeOrder(class.MemberOrder, action.Secondary)
 		}
 	case Change:
 		if member, ok := class.Members[action.Secondary].(T); ok {
 			// Change only if type matches.
-			member.SetFields(action.Fields)
+			errs.setFields(0, action, member)
 		}
+	case Rename:
+		if member, ok := class.Members[action.Secondary].(T); ok && action.Target != "" {
+			// Rename only if type matches, and the new name is unused.
+			if _, ok := class.Members[action.Target]; ok {
+				return
+			}
+			delete(class.Members, action.Secondary)
+			setMemberName(member, action.Target)
+			class.Members[action.Target] = member
+			class.MemberOrder = renameOrder(class.MemberOrder, action.Secondary, action.Target)
+			errs.setFields(0, action, member)
+		}
+	}
+}
+
+// setMemberName sets the name of a member.
+func setMemberName(member rbxdump.Member, name string) {
+	switch member := member.(type) {
+	case *rbxdump.Property:
+		member.Name = name
+	case *rbxdump.Function:
+		member.Name = name
+	case *rbxdump.Event:
+		member.Name = name
+	case *rbxdump.Callback:
+		member.Name = name
 	}
 }
 
@@ -219,14 +502,29 @@ type PatchProperty struct {
 
 // Patch implements the Patcher interface.
 func (member *PatchProperty) Patch(actions []Action) {
+	member.patch(actions, patchErrors{})
+}
+
+// PatchChecked implements the CheckedPatcher interface.
+func (member *PatchProperty) PatchChecked(actions []Action) error {
+	errs := newPatchErrors()
+	member.patch(actions, errs)
+	return errs.err()
+}
+
+func (member *PatchProperty) patch(actions []Action, errs patchErrors) {
 	if member.Property == nil {
 		member.Property = &rbxdump.Property{}
 	}
-	for _, action := range actions {
+	for i, action := range actions {
 		switch action.Element {
 		case Property:
-			if action.Type == Change {
-				member.SetFields(action.Fields)
+			switch action.Type {
+			case Change, Move:
+				errs.setFields(i, action, member.Property)
+			case Rename:
+				member.Name = action.Target
+				errs.setFields(i, action, member.Property)
 			}
 		}
 	}
@@ -240,14 +538,29 @@ type PatchFunction struct {
 
 // Patch implements the Patcher interface.
 func (member *PatchFunction) Patch(actions []Action) {
+	member.patch(actions, patchErrors{})
+}
+
+// PatchChecked implements the CheckedPatcher interface.
+func (member *PatchFunction) PatchChecked(actions []Action) error {
+	errs := newPatchErrors()
+	member.patch(actions, errs)
+	return errs.err()
+}
+
+func (member *PatchFunction) patch(actions []Action, errs patchErrors) {
 	if member.Function == nil {
 		member.Function = &rbxdump.Function{}
 	}
-	for _, action := range actions {
+	for i, action := range actions {
 		switch action.Element {
 		case Function:
-			if action.Type == Change {
-				member.SetFields(action.Fields)
+			switch action.Type {
+			case Change, Move:
+				errs.setFields(i, action, member.Function)
+			case Rename:
+				member.Name = action.Target
+				errs.setFields(i, action, member.Function)
 			}
 		}
 	}
@@ -261,14 +574,29 @@ type PatchEvent struct {
 
 // Patch implements the Patcher interface.
 func (member *PatchEvent) Patch(actions []Action) {
+	member.patch(actions, patchErrors{})
+}
+
+// PatchChecked implements the CheckedPatcher interface.
+func (member *PatchEvent) PatchChecked(actions []Action) error {
+	errs := newPatchErrors()
+	member.patch(actions, errs)
+	return errs.err()
+}
+
+func (member *PatchEvent) patch(actions []Action, errs patchErrors) {
 	if member.Event == nil {
 		member.Event = &rbxdump.Event{}
 	}
-	for _, action := range actions {
+	for i, action := range actions {
 		switch action.Element {
 		case Event:
-			if action.Type == Change {
-				member.SetFields(action.Fields)
+			switch action.Type {
+			case Change, Move:
+				errs.setFields(i, action, member.Event)
+			case Rename:
+				member.Name = action.Target
+				errs.setFields(i, action, member.Event)
 			}
 		}
 	}
@@ -282,14 +610,29 @@ type PatchCallback struct {
 
 // Patch implements the Patcher interface.
 func (member *PatchCallback) Patch(actions []Action) {
+	member.patch(actions, patchErrors{})
+}
+
+// PatchChecked implements the CheckedPatcher interface.
+func (member *PatchCallback) PatchChecked(actions []Action) error {
+	errs := newPatchErrors()
+	member.patch(actions, errs)
+	return errs.err()
+}
+
+func (member *PatchCallback) patch(actions []Action, errs patchErrors) {
 	if member.Callback == nil {
 		member.Callback = &rbxdump.Callback{}
 	}
-	for _, action := range actions {
+	for i, action := range actions {
 		switch action.Element {
 		case Callback:
-			if action.Type == Change {
-				member.SetFields(action.Fields)
+			switch action.Type {
+			case Change, Move:
+				errs.setFields(i, action, member.Callback)
+			case Rename:
+				member.Name = action.Target
+				errs.setFields(i, action, member.Callback)
 			}
 		}
 	}
@@ -303,33 +646,58 @@ type PatchEnum struct {
 
 // Patch implements the Patcher interface.
 func (enum *PatchEnum) Patch(actions []Action) {
+	enum.patch(actions, patchErrors{})
+}
+
+// PatchChecked implements the CheckedPatcher interface.
+func (enum *PatchEnum) PatchChecked(actions []Action) error {
+	errs := newPatchErrors()
+	enum.patch(actions, errs)
+	return errs.err()
+}
+
+func (enum *PatchEnum) patch(actions []Action, errs patchErrors) {
 	if enum.Enum == nil {
 		enum.Enum = &rbxdump.Enum{}
 	}
-	for _, action := range actions {
+	for i, action := range actions {
 		switch action.Element {
 		case Enum:
-			if action.Type == Change {
-				enum.SetFields(action.Fields)
+			switch action.Type {
+			case Change:
+				errs.setFields(i, action, enum.Enum)
+			case Rename:
+				enum.Name = action.Target
+				errs.setFields(i, action, enum.Enum)
 			}
 		case EnumItem:
 			switch action.Type {
 			case Add:
 				if item := enum.Items[action.Secondary]; item != nil {
-					item.SetFields(action.Fields)
+					errs.setFields(i, action, item)
 				} else {
 					item := rbxdump.EnumItem{Name: action.Secondary}
-					item.SetFields(action.Fields)
+					errs.setFields(i, action, &item)
 					if enum.Items == nil {
 						enum.Items = map[string]*rbxdump.EnumItem{}
 					}
 					enum.Items[action.Secondary] = &item
+					enum.ItemOrder = appendOrder(enum.ItemOrder, action.Secondary)
 				}
 			case Remove:
 				delete(enum.Items, action.Secondary)
+				enum.ItemOrder = removeOrder(enum.ItemOrder, action.Secondary)
 			case Change:
 				if item, ok := enum.Items[action.Secondary]; ok {
-					item.SetFields(action.Fields)
+					errs.setFields(i, action, item)
+				}
+			case Rename:
+				if item := enum.Items[action.Secondary]; item != nil && action.Target != "" && enum.Items[action.Target] == nil {
+					delete(enum.Items, action.Secondary)
+					item.Name = action.Target
+					enum.Items[action.Target] = item
+					enum.ItemOrder = renameOrder(enum.ItemOrder, action.Secondary, action.Target)
+					errs.setFields(i, action, item)
 				}
 			}
 		}
@@ -344,14 +712,29 @@ type PatchEnumItem struct {
 
 // Patch implements the Patcher interface.
 func (item *PatchEnumItem) Patch(actions []Action) {
+	item.patch(actions, patchErrors{})
+}
+
+// PatchChecked implements the CheckedPatcher interface.
+func (item *PatchEnumItem) PatchChecked(actions []Action) error {
+	errs := newPatchErrors()
+	item.patch(actions, errs)
+	return errs.err()
+}
+
+func (item *PatchEnumItem) patch(actions []Action, errs patchErrors) {
 	if item.EnumItem == nil {
 		item.EnumItem = &rbxdump.EnumItem{}
 	}
-	for _, action := range actions {
+	for i, action := range actions {
 		switch action.Element {
 		case EnumItem:
-			if action.Type == Change {
-				item.SetFields(action.Fields)
+			switch action.Type {
+			case Change:
+				errs.setFields(i, action, item.EnumItem)
+			case Rename:
+				item.Name = action.Target
+				errs.setFields(i, action, item.EnumItem)
 			}
 		}
 	}
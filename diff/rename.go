@@ -0,0 +1,323 @@
+package diff
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/robloxapi/rbxdump"
+)
+
+// renameThreshold is the minimum similarity between a removed and an added
+// element for them to be considered a rename.
+const renameThreshold = 0.75
+
+// similarity returns the fraction of fields of prev and next that are equal.
+// Returns 1 if there are no fields.
+func similarity(prev, next rbxdump.Fields) float64 {
+	n := len(next)
+	for name := range prev {
+		if _, ok := next[name]; !ok {
+			n++
+		}
+	}
+	if n == 0 {
+		return 1
+	}
+	return 1 - float64(len(compareFields(prev, next)))/float64(n)
+}
+
+// overlap returns the Jaccard index of the keys of two maps; the number of
+// keys in both, divided by the number of keys in either. Returns 1 if both
+// are empty.
+func overlap[P, N any](prev map[string]P, next map[string]N) float64 {
+	n, both := len(next), 0
+	for name := range prev {
+		if _, ok := next[name]; ok {
+			both++
+		} else {
+			n++
+		}
+	}
+	if n == 0 {
+		return 1
+	}
+	return float64(both) / float64(n)
+}
+
+// nameTokens returns the set of words in a name, split at changes of case,
+// between letters and digits, and at any other character. Words are
+// lowercased. For example, "GetGUIObjects2D" has the words "get", "gui",
+// "objects", "2", and "d".
+func nameTokens(name string) map[string]bool {
+	tokens := map[string]bool{}
+	runes := []rune(name)
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		split := i == len(runes) || !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i])
+		if !split && i > start {
+			p, c := runes[i-1], runes[i]
+			switch {
+			case unicode.IsDigit(p) != unicode.IsDigit(c):
+				split = true
+			case unicode.IsLower(p) && unicode.IsUpper(c):
+				split = true
+			case unicode.IsUpper(p) && unicode.IsUpper(c) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+				split = true
+			}
+		}
+		if !split {
+			continue
+		}
+		if i > start {
+			tokens[strings.ToLower(string(runes[start:i]))] = true
+		}
+		start = i
+		if i < len(runes) && !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+			start = i + 1
+		}
+	}
+	return tokens
+}
+
+// pairRenames pairs the names of removed elements with the names of added
+// elements, returning a map of each paired removed name to its added name.
+//
+// A pair for which strong returns true is always chosen, in order of removed
+// name, then added name. Among the remaining names, a pair is chosen if each is
+// the other's unique best match according to score, and the score is at least
+// renameThreshold. A negative score indicates that a pair is ineligible.
+func pairRenames(removed, added []string, strong func(prev, next string) bool, score func(prev, next string) float64) map[string]string {
+	sort.Strings(removed)
+	sort.Strings(added)
+	pairs := map[string]string{}
+	paired := map[string]bool{}
+	for _, p := range removed {
+		for _, n := range added {
+			if !paired[n] && strong(p, n) {
+				pairs[p] = n
+				paired[n] = true
+				break
+			}
+		}
+	}
+
+	type candidate struct {
+		prev, next string
+		score      float64
+	}
+	var candidates []candidate
+	for _, p := range removed {
+		if _, ok := pairs[p]; ok {
+			continue
+		}
+		for _, n := range added {
+			if paired[n] {
+				continue
+			}
+			if s := score(p, n); s >= renameThreshold {
+				candidates = append(candidates, candidate{prev: p, next: n, score: s})
+			}
+		}
+	}
+	// Find the best candidate for each name. A tie leaves a name without a
+	// best candidate.
+	bestNext := map[string]candidate{}
+	bestPrev := map[string]candidate{}
+	better := func(best map[string]candidate, name string, c candidate) {
+		b, ok := best[name]
+		switch {
+		case !ok || c.score > b.score:
+			best[name] = c
+		case c.score == b.score:
+			best[name] = candidate{score: c.score}
+		}
+	}
+	for _, c := range candidates {
+		better(bestNext, c.prev, c)
+		better(bestPrev, c.next, c)
+	}
+	for _, c := range candidates {
+		if bestNext[c.prev] == c && bestPrev[c.next] == c {
+			pairs[c.prev] = c.next
+		}
+	}
+	return pairs
+}
+
+// renamedClasses pairs classes removed from prev with classes added to next.
+// A pair is strong if the removed class prefers the added class. Otherwise,
+// the score is the mean of the similarity of fields and the overlap of member
+// names.
+func renamedClasses(prev, next *rbxdump.Root) map[string]string {
+	var removed, added []string
+	for name := range prev.Classes {
+		if next.Classes[name] == nil && prev.Classes[name] != nil {
+			removed = append(removed, name)
+		}
+	}
+	for name := range next.Classes {
+		if prev.Classes[name] == nil && next.Classes[name] != nil {
+			added = append(added, name)
+		}
+	}
+	return pairRenames(removed, added,
+		func(p, n string) bool {
+			return prev.Classes[p].PreferredDescriptor.Name == n
+		},
+		func(p, n string) float64 {
+			pc, nc := prev.Classes[p], next.Classes[n]
+			return (similarity(pc.Fields(nil), nc.Fields(nil)) + overlap(pc.Members, nc.Members)) / 2
+		},
+	)
+}
+
+// renamedEnums pairs enums removed from prev with enums added to next. A pair
+// is strong if the removed enum prefers the added enum. Otherwise, the score
+// is the mean of the similarity of fields and the overlap of item names.
+func renamedEnums(prev, next *rbxdump.Root) map[string]string {
+	var removed, added []string
+	for name := range prev.Enums {
+		if next.Enums[name] == nil && prev.Enums[name] != nil {
+			removed = append(removed, name)
+		}
+	}
+	for name := range next.Enums {
+		if prev.Enums[name] == nil && next.Enums[name] != nil {
+			added = append(added, name)
+		}
+	}
+	return pairRenames(removed, added,
+		func(p, n string) bool {
+			return prev.Enums[p].PreferredDescriptor.Name == n
+		},
+		func(p, n string) float64 {
+			pe, ne := prev.Enums[p], next.Enums[n]
+			return (similarity(pe.Fields(nil), ne.Fields(nil)) + overlap(pe.Items, ne.Items)) / 2
+		},
+	)
+}
+
+// renamedMembers pairs members removed from prev with members added to next.
+// Paired members must have the same member type. A pair is strong if the
+// removed member prefers the added member. Otherwise, properties must have the
+// same value type, other members must have the same signature, and the names
+// must share at least one word. The score is the mean of the similarity of
+// fields and the overlap of words in the names. Members in the excluded sets
+// of removed and added names are not paired.
+//
+// Members carry few fields, and unrelated members of a class often have equal
+// fields, so the names are compared to avoid pairing such members.
+func renamedMembers(prev, next *rbxdump.Class, excludeRemoved, excludeAdded map[string]bool) map[string]string {
+	var removed, added []string
+	for name, member := range prev.Members {
+		if _, ok := next.Members[name]; !ok && member != nil && !excludeRemoved[name] {
+			removed = append(removed, name)
+		}
+	}
+	for name, member := range next.Members {
+		if _, ok := prev.Members[name]; !ok && member != nil && !excludeAdded[name] {
+			added = append(added, name)
+		}
+	}
+	return pairRenames(removed, added,
+		func(p, n string) bool {
+			pm, nm := prev.Members[p], next.Members[n]
+			if pm.MemberType() != nm.MemberType() {
+				return false
+			}
+			pd, _ := pm.Fields(rbxdump.Fields{"PreferredDescriptor": nil})["PreferredDescriptor"].(rbxdump.PreferredDescriptor)
+			return pd.Name == n
+		},
+		func(p, n string) float64 {
+			pm, nm := prev.Members[p], next.Members[n]
+			if pm.MemberType() != nm.MemberType() {
+				return -1
+			}
+			if pp, ok := pm.(*rbxdump.Property); ok {
+				if pp.ValueType != nm.(*rbxdump.Property).ValueType {
+					return -1
+				}
+			} else {
+				ps, _ := rbxdump.MemberSignature(pm)
+				ns, _ := rbxdump.MemberSignature(nm)
+				if ps.String() != ns.String() {
+					return -1
+				}
+			}
+			words := overlap(nameTokens(p), nameTokens(n))
+			if words == 0 {
+				return -1
+			}
+			return (similarity(pm.Fields(nil), nm.Fields(nil)) + words) / 2
+		},
+	)
+}
+
+// renamedEnumItems pairs items removed from prev with items added to next. A
+// pair is strong if the added item lists the removed item among its legacy
+// names, or if the removed item prefers the added item. Otherwise, paired
+// items must have the same value, and the score is the similarity of fields.
+func renamedEnumItems(prev, next *rbxdump.Enum) map[string]string {
+	var removed, added []string
+	for name, item := range prev.Items {
+		if next.Items[name] == nil && item != nil {
+			removed = append(removed, name)
+		}
+	}
+	for name, item := range next.Items {
+		if prev.Items[name] == nil && item != nil {
+			added = append(added, name)
+		}
+	}
+	return pairRenames(removed, added,
+		func(p, n string) bool {
+			return slices.Contains(next.Items[n].LegacyNames, p) ||
+				prev.Items[p].PreferredDescriptor.Name == n
+		},
+		func(p, n string) float64 {
+			pi, ni := prev.Items[p], next.Items[n]
+			if pi.Value != ni.Value {
+				return -1
+			}
+			return similarity(pi.Fields(nil), ni.Fields(nil))
+		},
+	)
+}
+
+// renamedClass returns a shallow copy of class, with the name and fields of
+// next. Used to diff the members of a renamed class.
+func renamedClass(class, next *rbxdump.Class) *rbxdump.Class {
+	c := *class
+	c.Name = next.Name
+	c.SetFields(next.Fields(nil))
+	return &c
+}
+
+// renamedEnum returns a shallow copy of enum, with the name and fields of
+// next. Used to diff the items of a renamed enum.
+func renamedEnum(enum, next *rbxdump.Enum) *rbxdump.Enum {
+	e := *enum
+	e.Name = next.Name
+	e.SetFields(next.Fields(nil))
+	return &e
+}
+
+// renameTargets returns the set of names to which elements are renamed.
+func renameTargets(renames map[string]string) map[string]bool {
+	targets := make(map[string]bool, len(renames))
+	for _, name := range renames {
+		targets[name] = true
+	}
+	return targets
+}
+
+// renameFields returns the changed fields of a renamed element, or nil if no
+// fields changed.
+func renameFields(fields rbxdump.Fields) rbxdump.Fields {
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
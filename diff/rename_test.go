@@ -0,0 +1,89 @@
+package diff
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestPairRenames(t *testing.T) {
+	tests := []struct {
+		name           string
+		removed, added []string
+		strong         map[[2]string]bool
+		scores         map[[2]string]float64
+		want           map[string]string
+	}{
+		{
+			name:    "Strong",
+			removed: []string{"A", "B"},
+			added:   []string{"X", "Y"},
+			strong:  map[[2]string]bool{{"A", "Y"}: true, {"B", "Y"}: true},
+			scores:  map[[2]string]float64{{"B", "X"}: 0.9},
+			want:    map[string]string{"A": "Y", "B": "X"},
+		},
+		{
+			name:    "MutualBest",
+			removed: []string{"A", "B"},
+			added:   []string{"X", "Y"},
+			scores:  map[[2]string]float64{{"A", "X"}: 0.9, {"A", "Y"}: 0.8, {"B", "X"}: 0.85, {"B", "Y"}: 0.8},
+			want:    map[string]string{"A": "X"},
+		},
+		{
+			name:    "BothBest",
+			removed: []string{"A", "B"},
+			added:   []string{"X", "Y"},
+			scores:  map[[2]string]float64{{"A", "X"}: 0.9, {"A", "Y"}: 0.8, {"B", "X"}: 0.8, {"B", "Y"}: 0.9},
+			want:    map[string]string{"A": "X", "B": "Y"},
+		},
+		{
+			name:    "TiedNext",
+			removed: []string{"A"},
+			added:   []string{"X", "Y"},
+			scores:  map[[2]string]float64{{"A", "X"}: 0.9, {"A", "Y"}: 0.9},
+			want:    map[string]string{},
+		},
+		{
+			name:    "TiedPrev",
+			removed: []string{"A", "B"},
+			added:   []string{"X"},
+			scores:  map[[2]string]float64{{"A", "X"}: 0.9, {"B", "X"}: 0.9},
+			want:    map[string]string{},
+		},
+		{
+			name:    "TieBelowThreshold",
+			removed: []string{"A"},
+			added:   []string{"X", "Y"},
+			scores:  map[[2]string]float64{{"A", "X"}: 0.9, {"A", "Y"}: 0.5},
+			want:    map[string]string{"A": "X"},
+		},
+		{
+			name:    "AtThreshold",
+			removed: []string{"A"},
+			added:   []string{"X"},
+			scores:  map[[2]string]float64{{"A", "X"}: renameThreshold},
+			want:    map[string]string{"A": "X"},
+		},
+		{
+			name:    "BelowThreshold",
+			removed: []string{"A"},
+			added:   []string{"X"},
+			scores:  map[[2]string]float64{{"A", "X"}: renameThreshold - 0.01},
+			want:    map[string]string{},
+		},
+		{
+			name:    "Ineligible",
+			removed: []string{"A"},
+			added:   []string{"X"},
+			scores:  map[[2]string]float64{{"A", "X"}: -1},
+			want:    map[string]string{},
+		},
+	}
+	for _, test := range tests {
+		strong := func(prev, next string) bool { return test.strong[[2]string{prev, next}] }
+		score := func(prev, next string) float64 { return test.scores[[2]string{prev, next}] }
+		got := pairRenames(test.removed, test.added, strong, score)
+		if !maps.Equal(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package diff
+
+import (
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/robloxapi/rbxdump"
+)
+
+// Precondition indicates a condition that must hold for an action to be
+// applied by PatchStrict.
+type Precondition int
+
+const (
+	ValidAction    Precondition = iota // The action has a valid type and element, and any required target.
+	ElementExists                      // The element to which the action applies exists, with a matching type.
+	ElementAbsent                      // The element to be added, or the name to which an element is renamed or moved, is unused.
+	FieldsMatch                        // The fields of the element have their expected values.
+	FieldsSettable                     // The fields of the action can be set on the element.
+)
+
+// String returns a string representation of the precondition.
+func (p Precondition) String() string {
+	switch p {
+	case ValidAction:
+		return "ValidAction"
+	case ElementExists:
+		return "ElementExists"
+	case ElementAbsent:
+		return "ElementAbsent"
+	case FieldsMatch:
+		return "FieldsMatch"
+	case FieldsSettable:
+		return "FieldsSettable"
+	}
+	return "<invalid>"
+}
+
+// PreconditionError describes a precondition of an action that does not hold.
+type PreconditionError struct {
+	// Precondition is the condition that failed.
+	Precondition Precondition
+	// Fields contains the names of the fields that do not have their expected
+	// values, ordered by name. Applies only to FieldsMatch.
+	Fields []string
+	// Err is the error returned while setting fields. Applies only to
+	// FieldsSettable.
+	Err error
+}
+
+// Error implements the error interface.
+func (err PreconditionError) Error() string {
+	switch err.Precondition {
+	case ValidAction:
+		return "invalid action"
+	case ElementExists:
+		return "element does not exist"
+	case ElementAbsent:
+		return "element already exists"
+	case FieldsMatch:
+		return "unexpected value of fields " + strings.Join(err.Fields, ", ")
+	case FieldsSettable:
+		return err.Err.Error()
+	}
+	return "unknown precondition failed"
+}
+
+// Unwrap returns the underlying error, if any.
+func (err PreconditionError) Unwrap() error {
+	return err.Err
+}
+
+// PatchStrict is like PatchChecked, except that each action is verified
+// before it is applied. If any action fails, then no actions are applied, and
+// a PatchError is returned, listing each failed action with a
+// PreconditionError. Returns nil if every action was applied.
+//
+// Actions are verified in order, each against the result of applying the
+// preceding actions that succeeded. An action fails unless:
+//
+//   - The action has a valid type and element. A Rename must have a Target,
+//     and a Move must apply to a member, with a Target naming another class.
+//   - For Add, the element does not exist. For other types, the element
+//     exists, and a member has the type indicated by the action.
+//   - For members and enum items, the outer class or enum exists.
+//   - For Rename and Move, no element of the same kind has the target name.
+//   - If the action records previous values in Prev, then the element's
+//     fields have those values. For Remove, if the action has fields, then
+//     the element's fields also have those values.
+//   - The fields of the action can be set, as by PatchChecked.
+func (root *Patch) PatchStrict(actions []Action) error {
+	base := root.Root
+	if base == nil {
+		base = &rbxdump.Root{}
+	}
+	work := Patch{base.Copy()}
+	var errs PatchError
+	for i, action := range actions {
+		err := work.check(action)
+		if err == nil {
+			err = work.settable(action)
+		}
+		if err != nil {
+			errs = append(errs, ActionError{Index: i, Action: action, Err: err})
+			continue
+		}
+		work.Patch(actions[i : i+1])
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	root.Patch(actions)
+	return nil
+}
+
+// check verifies the preconditions of an action against the root, except for
+// whether fields can be set.
+func (root Patch) check(action Action) error {
+	if !action.Element.IsValid() || action.Type < Remove || action.Type > Move {
+		return PreconditionError{Precondition: ValidAction}
+	}
+	if action.Type == Rename && action.Target == "" {
+		return PreconditionError{Precondition: ValidAction}
+	}
+	if action.Type == Move && (!action.Element.IsMember() || action.Target == "" || action.Target == action.Primary) {
+		return PreconditionError{Precondition: ValidAction}
+	}
+
+	// Verify that the outer element exists.
+	switch {
+	case action.Element.IsMember():
+		if root.Classes[action.Primary] == nil {
+			return PreconditionError{Precondition: ElementExists}
+		}
+	case action.Element == EnumItem:
+		if root.Enums[action.Primary] == nil {
+			return PreconditionError{Precondition: ElementExists}
+		}
+	}
+
+	element := root.element(action.Element, action.Primary, action.Secondary)
+	if action.Type == Add {
+		if root.exists(action.Element, action.Primary, action.Secondary) {
+			return PreconditionError{Precondition: ElementAbsent}
+		}
+		return nil
+	}
+	if element == nil || FromElement(element) != action.Element {
+		return PreconditionError{Precondition: ElementExists}
+	}
+
+	expected := action.Prev
+	if action.Type == Remove && len(action.Fields) > 0 {
+		expected = maps.Clone(action.Fields)
+		maps.Copy(expected, action.Prev)
+	}
+	if fields := mismatchedFields(element.Fields(nil), expected); len(fields) > 0 {
+		return PreconditionError{Precondition: FieldsMatch, Fields: fields}
+	}
+
+	switch action.Type {
+	case Rename:
+		primary, secondary := action.Primary, action.Target
+		if action.Element == Class || action.Element == Enum {
+			primary, secondary = action.Target, ""
+		}
+		if root.exists(action.Element, primary, secondary) {
+			return PreconditionError{Precondition: ElementAbsent}
+		}
+	case Move:
+		if root.Classes[action.Target] == nil {
+			return PreconditionError{Precondition: ElementExists}
+		}
+		if root.exists(action.Element, action.Target, action.Secondary) {
+			return PreconditionError{Precondition: ElementAbsent}
+		}
+	}
+	return nil
+}
+
+// settable verifies that the fields of an action can be set on its element,
+// without modifying the element. The fields are set on a new element with the
+// fields of the existing element, if any.
+func (root Patch) settable(action Action) error {
+	if action.Type == Remove || len(action.Fields) == 0 {
+		return nil
+	}
+	f := action.ToFielder()
+	if element := root.element(action.Element, action.Primary, action.Secondary); element != nil && action.Type != Add {
+		f.SetFields(element.Fields(nil))
+	}
+	if err := rbxdump.SetFields(f, action.Fields); err != nil {
+		return PreconditionError{Precondition: FieldsSettable, Err: err}
+	}
+	return nil
+}
+
+// exists returns whether the root has an element located by the given names.
+// A member of any type is considered to exist.
+func (root Patch) exists(e Element, primary, secondary string) bool {
+	if e.IsMember() {
+		if class := root.Classes[primary]; class != nil {
+			return class.Members[secondary] != nil
+		}
+		return false
+	}
+	return root.element(e, primary, secondary) != nil
+}
+
+// mismatchedFields returns the names of the fields in expected that do not
+// have the same value in fields, ordered by name.
+func mismatchedFields(fields, expected rbxdump.Fields) (names []string) {
+	for name, value := range expected {
+		if !fieldsEqual(rbxdump.Fields{name: fields[name]}, rbxdump.Fields{name: value}) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,153 @@
+package diff
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/robloxapi/rbxdump"
+)
+
+func strictBase() *rbxdump.Root {
+	return &rbxdump.Root{
+		Classes: map[string]*rbxdump.Class{
+			"Model": {Name: "Model", Members: map[string]rbxdump.Member{}},
+			"Part": {Name: "Part", Members: map[string]rbxdump.Member{
+				"Size": &rbxdump.Property{Name: "Size", Category: "Data"},
+			}},
+		},
+		Enums: map[string]*rbxdump.Enum{
+			"Material": {Name: "Material", Items: map[string]*rbxdump.EnumItem{
+				"Plastic": {Name: "Plastic", Value: 256},
+			}},
+		},
+	}
+}
+
+func TestPatchStrict(t *testing.T) {
+	changeSize := func(fields, prev rbxdump.Fields) Action {
+		return Action{Type: Change, Element: Property, Primary: "Part", Secondary: "Size", Fields: fields, Prev: prev}
+	}
+	type failure struct {
+		index        int
+		precondition Precondition
+	}
+	tests := []struct {
+		name    string
+		nilRoot bool
+		actions []Action
+		want    []failure
+	}{
+		{
+			name: "Applied",
+			actions: []Action{
+				{Type: Add, Element: Class, Primary: "Tool"},
+				// Verified against the result of the preceding action.
+				{Type: Add, Element: Property, Primary: "Tool", Secondary: "Grip"},
+				changeSize(rbxdump.Fields{"Category": "Appearance"}, rbxdump.Fields{"Category": "Data"}),
+			},
+		},
+		{
+			name: "ValidAction",
+			actions: []Action{
+				{Type: Rename, Element: Class, Primary: "Part"},
+				{Type: Move, Element: Class, Primary: "Part", Target: "Model"},
+				{Type: Move, Element: Property, Primary: "Part", Secondary: "Size", Target: "Part"},
+				{Type: Add, Element: Invalid, Primary: "Part"},
+			},
+			want: []failure{{0, ValidAction}, {1, ValidAction}, {2, ValidAction}, {3, ValidAction}},
+		},
+		{
+			name: "ElementExists",
+			actions: []Action{
+				{Type: Remove, Element: Class, Primary: "Tool"},
+				{Type: Add, Element: Property, Primary: "Tool", Secondary: "Grip"},
+				{Type: Change, Element: Function, Primary: "Part", Secondary: "Size"},
+				{Type: Move, Element: Property, Primary: "Part", Secondary: "Size", Target: "Tool"},
+				{Type: Remove, Element: EnumItem, Primary: "Material", Secondary: "Wood"},
+			},
+			want: []failure{{0, ElementExists}, {1, ElementExists}, {2, ElementExists}, {3, ElementExists}, {4, ElementExists}},
+		},
+		{
+			name: "ElementAbsent",
+			actions: []Action{
+				{Type: Add, Element: Class, Primary: "Part"},
+				{Type: Add, Element: Function, Primary: "Part", Secondary: "Size"},
+				{Type: Rename, Element: Class, Primary: "Model", Target: "Part"},
+				{Type: Add, Element: Property, Primary: "Model", Secondary: "Size"},
+				// Verified against the result of the preceding action.
+				{Type: Move, Element: Property, Primary: "Part", Secondary: "Size", Target: "Model"},
+			},
+			want: []failure{{0, ElementAbsent}, {1, ElementAbsent}, {2, ElementAbsent}, {4, ElementAbsent}},
+		},
+		{
+			name: "FieldsMatch",
+			actions: []Action{
+				changeSize(rbxdump.Fields{"Category": "Appearance"}, rbxdump.Fields{"Category": "Behavior"}),
+				{Type: Remove, Element: EnumItem, Primary: "Material", Secondary: "Plastic", Fields: rbxdump.Fields{"Value": 512}},
+			},
+			want: []failure{{0, FieldsMatch}, {1, FieldsMatch}},
+		},
+		{
+			name: "FieldsSettable",
+			actions: []Action{
+				changeSize(rbxdump.Fields{"Category": "Appearance", "Unknown": true}, nil),
+				// The settable fields of the failed action are not applied
+				// to the working copy, so the previous value still matches.
+				changeSize(rbxdump.Fields{"Category": "Behavior"}, rbxdump.Fields{"Category": "Data"}),
+			},
+			want: []failure{{0, FieldsSettable}},
+		},
+		{
+			name:    "NilRoot",
+			nilRoot: true,
+			actions: []Action{
+				{Type: Add, Element: Class, Primary: "Tool"},
+				{Type: Remove, Element: Class, Primary: "Part"},
+			},
+			want: []failure{{1, ElementExists}},
+		},
+	}
+	for _, test := range tests {
+		patch := &Patch{strictBase()}
+		if test.nilRoot {
+			patch.Root = nil
+		}
+		err := patch.PatchStrict(test.actions)
+		var got []failure
+		if err != nil {
+			var perr PatchError
+			if !errors.As(err, &perr) {
+				t.Errorf("%s: expected PatchError, got %v", test.name, err)
+				continue
+			}
+			for _, e := range perr {
+				var cerr PreconditionError
+				if !errors.As(e.Err, &cerr) {
+					t.Errorf("%s: action %d: expected PreconditionError, got %v", test.name, e.Index, e.Err)
+					continue
+				}
+				got = append(got, failure{e.Index, cerr.Precondition})
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got failures %v, want %v", test.name, got, test.want)
+		}
+		if len(test.want) == 0 {
+			want := strictBase()
+			(&Patch{want}).Patch(test.actions)
+			if !reflect.DeepEqual(patch.Root, want) {
+				t.Errorf("%s: result differs from Patch", test.name)
+			}
+			continue
+		}
+		// A failed patch leaves the receiver unchanged.
+		want := strictBase()
+		if test.nilRoot {
+			want = nil
+		}
+		if !reflect.DeepEqual(patch.Root, want) {
+			t.Errorf("%s: receiver was changed", test.name)
+		}
+	}
+}
@@ -34,6 +34,8 @@ type Fielder interface {
 	//
 	// Implementations must not retain received values; they should be copied if
 	// necessary.
+	//
+	// Fields that cannot be set are ignored. See CheckedFielder to report them.
 	SetFields(f Fields)
 }
 
@@ -165,6 +167,7 @@ func convertSlice[T any](v *[]T, u any, get func(v *T, u any) bool) bool {
 			return false
 		}
 		*v = ts[:]
+		return true
 	case []T:
 		*v = slices.Clone(u)
 		return true
@@ -203,6 +206,14 @@ func normalizeReturnType(v *[]Type, fields Fields, name string) bool {
 type Root struct {
 	Classes map[string]*Class
 	Enums   map[string]*Enum
+
+	// ClassOrder optionally lists the names of classes in a preferred order,
+	// such as the order in which they appeared in a source. Names that do not
+	// refer to a class are ignored.
+	ClassOrder []string
+	// EnumOrder optionally lists the names of enums in a preferred order.
+	// Names that do not refer to an enum are ignored.
+	EnumOrder []string
 }
 
 // sortClasses sorts Class values by name.
@@ -251,6 +262,8 @@ func (root *Root) Copy() *Root {
 	for name, enum := range root.Enums {
 		croot.Enums[name] = enum.Copy()
 	}
+	croot.ClassOrder = slices.Clone(root.ClassOrder)
+	croot.EnumOrder = slices.Clone(root.EnumOrder)
 	return croot
 }
 
@@ -262,6 +275,11 @@ type Class struct {
 	Members             map[string]Member
 	PreferredDescriptor PreferredDescriptor
 	Tags
+
+	// MemberOrder optionally lists the names of members in a preferred order,
+	// such as the order in which they appeared in a source. Names that do not
+	// refer to a member are ignored.
+	MemberOrder []string
 }
 
 // Member represents a member of a Class.
@@ -276,6 +294,10 @@ type Member interface {
 	MemberName() string
 	// MemberCopy returns a deep copy of the member.
 	MemberCopy() Member
+	// MemberEqual returns whether the member is equal to another member.
+	MemberEqual(other Member) bool
+	// MemberHash returns a content hash of the member.
+	MemberHash() Hash
 }
 
 // sortMembers sorts Member values by name.
@@ -303,6 +325,7 @@ func (class *Class) Copy() *Class {
 		cclass.Members[name] = member.MemberCopy()
 	}
 	cclass.Tags = class.GetTags()
+	cclass.MemberOrder = slices.Clone(class.MemberOrder)
 	return &cclass
 }
 
@@ -335,10 +358,17 @@ func (class *Class) Fields(fields Fields) Fields {
 
 // SetFields implements the Fielder interface.
 func (class *Class) SetFields(fields Fields) {
-	normalize[string](&class.Superclass, fields, "Superclass")
-	normalize[string](&class.MemoryCategory, fields, "MemoryCategory")
-	normalizeType(&class.PreferredDescriptor, fields, "PreferredDescriptor")
-	normalizeType(&class.Tags, fields, "Tags")
+	class.SetFieldsChecked(fields)
+}
+
+// SetFieldsChecked implements the CheckedFielder interface.
+func (class *Class) SetFieldsChecked(fields Fields) error {
+	c := fieldChecker{fields: fields}
+	c.check("Superclass", normalize[string](&class.Superclass, fields, "Superclass"))
+	c.check("MemoryCategory", normalize[string](&class.MemoryCategory, fields, "MemoryCategory"))
+	c.check("PreferredDescriptor", normalizeType(&class.PreferredDescriptor, fields, "PreferredDescriptor"))
+	c.check("Tags", normalizeType(&class.Tags, fields, "Tags"))
+	return c.err()
 }
 
 // Property is a Member that represents a class property.
@@ -354,6 +384,11 @@ type Property struct {
 	ThreadSafety        string
 	PreferredDescriptor PreferredDescriptor
 	Tags
+
+	// NoDefault indicates that the property has no default, as opposed to an
+	// empty default, such as when a source omits the default. Not included in
+	// Fields.
+	NoDefault bool
 }
 
 // member implements the Member interface.
@@ -426,16 +461,23 @@ func (member *Property) Fields(fields Fields) Fields {
 }
 
 func (member *Property) SetFields(fields Fields) {
-	normalizeType(&member.ValueType, fields, "ValueType")
-	normalize[string](&member.Default, fields, "Default")
-	normalize[string](&member.Category, fields, "Category")
-	normalize[string](&member.ReadSecurity, fields, "ReadSecurity")
-	normalize[string](&member.WriteSecurity, fields, "WriteSecurity")
-	normalize[bool](&member.CanLoad, fields, "CanLoad")
-	normalize[bool](&member.CanSave, fields, "CanSave")
-	normalize[string](&member.ThreadSafety, fields, "ThreadSafety")
-	normalizeType(&member.PreferredDescriptor, fields, "PreferredDescriptor")
-	normalizeType(&member.Tags, fields, "Tags")
+	member.SetFieldsChecked(fields)
+}
+
+// SetFieldsChecked implements the CheckedFielder interface.
+func (member *Property) SetFieldsChecked(fields Fields) error {
+	c := fieldChecker{fields: fields}
+	c.check("ValueType", normalizeType(&member.ValueType, fields, "ValueType"))
+	c.check("Default", normalize[string](&member.Default, fields, "Default"))
+	c.check("Category", normalize[string](&member.Category, fields, "Category"))
+	c.check("ReadSecurity", normalize[string](&member.ReadSecurity, fields, "ReadSecurity"))
+	c.check("WriteSecurity", normalize[string](&member.WriteSecurity, fields, "WriteSecurity"))
+	c.check("CanLoad", normalize[bool](&member.CanLoad, fields, "CanLoad"))
+	c.check("CanSave", normalize[bool](&member.CanSave, fields, "CanSave"))
+	c.check("ThreadSafety", normalize[string](&member.ThreadSafety, fields, "ThreadSafety"))
+	c.check("PreferredDescriptor", normalizeType(&member.PreferredDescriptor, fields, "PreferredDescriptor"))
+	c.check("Tags", normalizeType(&member.Tags, fields, "Tags"))
+	return c.err()
 }
 
 func (member *Property) MarshalJSON() ([]byte, error) {
@@ -517,12 +559,19 @@ func (member *Function) Fields(fields Fields) Fields {
 
 // SetFields implements the Fielder interface.
 func (member *Function) SetFields(fields Fields) {
-	normalizeParameters(&member.Parameters, fields, "Parameters")
-	normalizeReturnType(&member.ReturnType, fields, "ReturnType")
-	normalize[string](&member.Security, fields, "Security")
-	normalize[string](&member.ThreadSafety, fields, "ThreadSafety")
-	normalizeType(&member.PreferredDescriptor, fields, "PreferredDescriptor")
-	normalizeType(&member.Tags, fields, "Tags")
+	member.SetFieldsChecked(fields)
+}
+
+// SetFieldsChecked implements the CheckedFielder interface.
+func (member *Function) SetFieldsChecked(fields Fields) error {
+	c := fieldChecker{fields: fields}
+	c.check("Parameters", normalizeParameters(&member.Parameters, fields, "Parameters"))
+	c.check("ReturnType", normalizeReturnType(&member.ReturnType, fields, "ReturnType"))
+	c.check("Security", normalize[string](&member.Security, fields, "Security"))
+	c.check("ThreadSafety", normalize[string](&member.ThreadSafety, fields, "ThreadSafety"))
+	c.check("PreferredDescriptor", normalizeType(&member.PreferredDescriptor, fields, "PreferredDescriptor"))
+	c.check("Tags", normalizeType(&member.Tags, fields, "Tags"))
+	return c.err()
 }
 
 func (member *Function) MarshalJSON() ([]byte, error) {
@@ -600,11 +649,18 @@ func (member *Event) Fields(fields Fields) Fields {
 
 // SetFields implements the Fielder interface.
 func (member *Event) SetFields(fields Fields) {
-	normalizeParameters(&member.Parameters, fields, "Parameters")
-	normalize[string](&member.Security, fields, "Security")
-	normalize[string](&member.ThreadSafety, fields, "ThreadSafety")
-	normalizeType(&member.PreferredDescriptor, fields, "PreferredDescriptor")
-	normalizeType(&member.Tags, fields, "Tags")
+	member.SetFieldsChecked(fields)
+}
+
+// SetFieldsChecked implements the CheckedFielder interface.
+func (member *Event) SetFieldsChecked(fields Fields) error {
+	c := fieldChecker{fields: fields}
+	c.check("Parameters", normalizeParameters(&member.Parameters, fields, "Parameters"))
+	c.check("Security", normalize[string](&member.Security, fields, "Security"))
+	c.check("ThreadSafety", normalize[string](&member.ThreadSafety, fields, "ThreadSafety"))
+	c.check("PreferredDescriptor", normalizeType(&member.PreferredDescriptor, fields, "PreferredDescriptor"))
+	c.check("Tags", normalizeType(&member.Tags, fields, "Tags"))
+	return c.err()
 }
 
 func (member *Event) MarshalJSON() ([]byte, error) {
@@ -686,12 +742,19 @@ func (member *Callback) Fields(fields Fields) Fields {
 
 // SetFields implements the Fielder interface.
 func (member *Callback) SetFields(fields Fields) {
-	normalizeParameters(&member.Parameters, fields, "Parameters")
-	normalizeReturnType(&member.ReturnType, fields, "ReturnType")
-	normalize[string](&member.Security, fields, "Security")
-	normalize[string](&member.ThreadSafety, fields, "ThreadSafety")
-	normalizeType(&member.PreferredDescriptor, fields, "PreferredDescriptor")
-	normalizeType(&member.Tags, fields, "Tags")
+	member.SetFieldsChecked(fields)
+}
+
+// SetFieldsChecked implements the CheckedFielder interface.
+func (member *Callback) SetFieldsChecked(fields Fields) error {
+	c := fieldChecker{fields: fields}
+	c.check("Parameters", normalizeParameters(&member.Parameters, fields, "Parameters"))
+	c.check("ReturnType", normalizeReturnType(&member.ReturnType, fields, "ReturnType"))
+	c.check("Security", normalize[string](&member.Security, fields, "Security"))
+	c.check("ThreadSafety", normalize[string](&member.ThreadSafety, fields, "ThreadSafety"))
+	c.check("PreferredDescriptor", normalizeType(&member.PreferredDescriptor, fields, "PreferredDescriptor"))
+	c.check("Tags", normalizeType(&member.Tags, fields, "Tags"))
+	return c.err()
 }
 
 func (member *Callback) MarshalJSON() ([]byte, error) {
@@ -707,6 +770,11 @@ type Enum struct {
 	Items               map[string]*EnumItem
 	PreferredDescriptor PreferredDescriptor
 	Tags
+
+	// ItemOrder optionally lists the names of items in a preferred order, such
+	// as the order in which they appeared in a source. Names that do not refer
+	// to an item are ignored.
+	ItemOrder []string
 }
 
 // sortEnumItems sorts Member values by Index, then Value, then Name.
@@ -742,6 +810,7 @@ func (enum *Enum) Copy() *Enum {
 		cenum.Items[name] = item.Copy()
 	}
 	cenum.Tags = Tags(enum.GetTags())
+	cenum.ItemOrder = slices.Clone(enum.ItemOrder)
 	return &cenum
 }
 
@@ -768,8 +837,15 @@ func (enum *Enum) Fields(fields Fields) Fields {
 
 // SetFields implements the Fielder interface.
 func (enum *Enum) SetFields(fields Fields) {
-	normalizeType(&enum.PreferredDescriptor, fields, "PreferredDescriptor")
-	normalizeType(&enum.Tags, fields, "Tags")
+	enum.SetFieldsChecked(fields)
+}
+
+// SetFieldsChecked implements the CheckedFielder interface.
+func (enum *Enum) SetFieldsChecked(fields Fields) error {
+	c := fieldChecker{fields: fields}
+	c.check("PreferredDescriptor", normalizeType(&enum.PreferredDescriptor, fields, "PreferredDescriptor"))
+	c.check("Tags", normalizeType(&enum.Tags, fields, "Tags"))
+	return c.err()
 }
 
 // EnumItem represents an enum item.
@@ -822,11 +898,18 @@ func (item *EnumItem) Fields(fields Fields) Fields {
 
 // SetFields implements the Fielder interface.
 func (item *EnumItem) SetFields(fields Fields) {
-	normalizeNumber(&item.Value, fields, "Value")
-	normalizeNumber(&item.Index, fields, "Index")
-	normalizeSlice(&item.LegacyNames, fields, "LegacyNames", convert)
-	normalizeType(&item.PreferredDescriptor, fields, "PreferredDescriptor")
-	normalizeType(&item.Tags, fields, "Tags")
+	item.SetFieldsChecked(fields)
+}
+
+// SetFieldsChecked implements the CheckedFielder interface.
+func (item *EnumItem) SetFieldsChecked(fields Fields) error {
+	c := fieldChecker{fields: fields}
+	c.check("Value", normalizeNumber(&item.Value, fields, "Value"))
+	c.check("Index", normalizeNumber(&item.Index, fields, "Index"))
+	c.check("LegacyNames", normalizeSlice(&item.LegacyNames, fields, "LegacyNames", convert))
+	c.check("PreferredDescriptor", normalizeType(&item.PreferredDescriptor, fields, "PreferredDescriptor"))
+	c.check("Tags", normalizeType(&item.Tags, fields, "Tags"))
+	return c.err()
 }
 
 // Parameter represents a parameter of a function, event, or callback member.
@@ -909,6 +992,13 @@ func (t *Type) normalize(u any) bool {
 	case *Type:
 		*t = *u
 		return true
+	case string:
+		v, ok := ParseType(u)
+		if !ok {
+			return false
+		}
+		*t = v
+		return true
 	case map[string]any:
 		var v Type
 		if !convert(&v.Category, u["Category"]) {
@@ -917,8 +1007,8 @@ func (t *Type) normalize(u any) bool {
 		if !convert(&v.Name, u["Name"]) {
 			return false
 		}
-		if strings.HasSuffix(v.Name, "?") {
-			v.Name = strings.TrimSuffix(v.Name, "?")
+		var ok bool
+		if v.Name, ok = strings.CutSuffix(v.Name, "?"); ok {
 			v.Optional = true
 		} else {
 			convert(&v.Optional, u["Optional"])
@@ -0,0 +1,439 @@
+package rbxdump
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"slices"
+	"sort"
+)
+
+// tagSet returns the tags as a sorted list without duplicates.
+func tagSet(tags Tags) []string {
+	set := tags.GetTags()
+	sort.Strings(set)
+	return slices.Compact(set)
+}
+
+// equalParams returns whether two parameter lists are equal. The Default of a
+// parameter is compared only if the parameter is optional.
+func equalParams(a, b []Parameter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || a[i].Name != b[i].Name || a[i].Optional != b[i].Optional {
+			return false
+		}
+		if a[i].Optional && a[i].Default != b[i].Default {
+			return false
+		}
+	}
+	return true
+}
+
+// equalValue returns whether two field values are equal. Only handles field
+// types returned by rbxdump elements. Tags are compared as sets.
+func equalValue(a, b any) bool {
+	switch a := a.(type) {
+	case string, bool, int, Type, PreferredDescriptor:
+		return a == b
+	case []string:
+		b, ok := b.([]string)
+		return ok && slices.Equal(a, b)
+	case []Type:
+		b, ok := b.([]Type)
+		return ok && slices.Equal(a, b)
+	case []Parameter:
+		b, ok := b.([]Parameter)
+		return ok && equalParams(a, b)
+	case Tags:
+		b, ok := b.(Tags)
+		return ok && slices.Equal(tagSet(a), tagSet(b))
+	}
+	return false
+}
+
+// equalFields returns whether the fields of a and b are equal.
+func equalFields(a, b Fielder) bool {
+	fa := a.Fields(nil)
+	fb := b.Fields(nil)
+	if len(fa) != len(fb) {
+		return false
+	}
+	for name, v := range fa {
+		u, ok := fb[name]
+		if !ok || !equalValue(v, u) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether the root is equal to other. Two roots are equal if
+// they have equal classes and enums under the same keys. Tags are compared as
+// sets.
+func (root *Root) Equal(other *Root) bool {
+	if root == nil || other == nil {
+		return root == other
+	}
+	if len(root.Classes) != len(other.Classes) || len(root.Enums) != len(other.Enums) {
+		return false
+	}
+	for name, class := range root.Classes {
+		c, ok := other.Classes[name]
+		if !ok || !class.Equal(c) {
+			return false
+		}
+	}
+	for name, enum := range root.Enums {
+		e, ok := other.Enums[name]
+		if !ok || !enum.Equal(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether the class is equal to other, including members.
+// Tags are compared as sets.
+func (class *Class) Equal(other *Class) bool {
+	if class == nil || other == nil {
+		return class == other
+	}
+	if class.Name != other.Name || !equalFields(class, other) {
+		return false
+	}
+	if len(class.Members) != len(other.Members) {
+		return false
+	}
+	for name, member := range class.Members {
+		m, ok := other.Members[name]
+		if !ok {
+			return false
+		}
+		if member == nil || m == nil {
+			if member != m {
+				return false
+			}
+			continue
+		}
+		if !member.MemberEqual(m) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalMember returns whether two members are of the same type and have equal
+// names and fields.
+func equalMember(a, b Member) bool {
+	if b == nil {
+		return false
+	}
+	return a.MemberType() == b.MemberType() &&
+		a.MemberName() == b.MemberName() &&
+		equalFields(a, b)
+}
+
+// MemberEqual returns whether the member is equal to other.
+//
+// MemberEqual implements the Member interface.
+func (member *Property) MemberEqual(other Member) bool { return equalMember(member, other) }
+
+// Equal returns whether the property is equal to other. Tags are compared as
+// sets.
+func (member *Property) Equal(other *Property) bool {
+	if member == nil || other == nil {
+		return member == other
+	}
+	return equalMember(member, other)
+}
+
+// MemberEqual returns whether the member is equal to other.
+//
+// MemberEqual implements the Member interface.
+func (member *Function) MemberEqual(other Member) bool { return equalMember(member, other) }
+
+// Equal returns whether the function is equal to other. Tags are compared as
+// sets.
+func (member *Function) Equal(other *Function) bool {
+	if member == nil || other == nil {
+		return member == other
+	}
+	return equalMember(member, other)
+}
+
+// MemberEqual returns whether the member is equal to other.
+//
+// MemberEqual implements the Member interface.
+func (member *Event) MemberEqual(other Member) bool { return equalMember(member, other) }
+
+// Equal returns whether the event is equal to other. Tags are compared as
+// sets.
+func (member *Event) Equal(other *Event) bool {
+	if member == nil || other == nil {
+		return member == other
+	}
+	return equalMember(member, other)
+}
+
+// MemberEqual returns whether the member is equal to other.
+//
+// MemberEqual implements the Member interface.
+func (member *Callback) MemberEqual(other Member) bool { return equalMember(member, other) }
+
+// Equal returns whether the callback is equal to other. Tags are compared as
+// sets.
+func (member *Callback) Equal(other *Callback) bool {
+	if member == nil || other == nil {
+		return member == other
+	}
+	return equalMember(member, other)
+}
+
+// Equal returns whether the enum is equal to other, including items. Tags are
+// compared as sets.
+func (enum *Enum) Equal(other *Enum) bool {
+	if enum == nil || other == nil {
+		return enum == other
+	}
+	if enum.Name != other.Name || !equalFields(enum, other) {
+		return false
+	}
+	if len(enum.Items) != len(other.Items) {
+		return false
+	}
+	for name, item := range enum.Items {
+		i, ok := other.Items[name]
+		if !ok || !item.Equal(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether the enum item is equal to other. Tags are compared as
+// sets.
+func (item *EnumItem) Equal(other *EnumItem) bool {
+	if item == nil || other == nil {
+		return item == other
+	}
+	return item.Name == other.Name && equalFields(item, other)
+}
+
+// Hash is a content hash of an element. Elements that are equal have equal
+// hashes. The hash does not depend on the iteration order of maps, or the
+// order of tags.
+type Hash [sha256.Size]byte
+
+// String returns the hash as a hexadecimal string.
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// hasher writes values to a hash in a canonical encoding.
+type hasher struct {
+	h   hash.Hash
+	buf [binary.MaxVarintLen64]byte
+}
+
+func newHasher() *hasher {
+	return &hasher{h: sha256.New()}
+}
+
+func (h *hasher) sum() (sum Hash) {
+	h.h.Sum(sum[:0])
+	return sum
+}
+
+func (h *hasher) writeInt(v int) {
+	h.h.Write(binary.AppendVarint(h.buf[:0], int64(v)))
+}
+
+func (h *hasher) writeBool(v bool) {
+	if v {
+		h.writeInt(1)
+	} else {
+		h.writeInt(0)
+	}
+}
+
+func (h *hasher) writeString(s string) {
+	h.writeInt(len(s))
+	h.h.Write([]byte(s))
+}
+
+func (h *hasher) writeStrings(list []string) {
+	h.writeInt(len(list))
+	for _, s := range list {
+		h.writeString(s)
+	}
+}
+
+func (h *hasher) writeHash(sum Hash) {
+	h.h.Write(sum[:])
+}
+
+func (h *hasher) writeType(typ Type) {
+	h.writeString(typ.Category)
+	h.writeString(typ.Name)
+	h.writeBool(typ.Optional)
+}
+
+// writeValue writes a field value, prefixed by a byte identifying its type.
+func (h *hasher) writeValue(v any) {
+	switch v := v.(type) {
+	case string:
+		h.writeString("s")
+		h.writeString(v)
+	case bool:
+		h.writeString("b")
+		h.writeBool(v)
+	case int:
+		h.writeString("i")
+		h.writeInt(v)
+	case Type:
+		h.writeString("t")
+		h.writeType(v)
+	case []Type:
+		h.writeString("T")
+		h.writeInt(len(v))
+		for _, typ := range v {
+			h.writeType(typ)
+		}
+	case []Parameter:
+		h.writeString("P")
+		h.writeInt(len(v))
+		for _, param := range v {
+			h.writeType(param.Type)
+			h.writeString(param.Name)
+			h.writeBool(param.Optional)
+			if param.Optional {
+				h.writeString(param.Default)
+			}
+		}
+	case PreferredDescriptor:
+		h.writeString("p")
+		h.writeString(v.Name)
+		h.writeString(v.ThreadSafety)
+	case Tags:
+		h.writeString("g")
+		h.writeStrings(tagSet(v))
+	case []string:
+		h.writeString("S")
+		h.writeStrings(v)
+	default:
+		h.writeString("")
+	}
+}
+
+// writeFields writes the fields of f, ordered by name.
+func (h *hasher) writeFields(f Fielder) {
+	fields := f.Fields(nil)
+	h.writeInt(len(fields))
+	for _, name := range sortedKeys(fields) {
+		h.writeString(name)
+		h.writeValue(fields[name])
+	}
+}
+
+// Hash returns a content hash of the root, including every class and enum.
+func (root *Root) Hash() Hash {
+	h := newHasher()
+	h.writeString("Root")
+	h.writeInt(len(root.Classes))
+	for _, name := range sortedKeys(root.Classes) {
+		h.writeString(name)
+		class := root.Classes[name]
+		h.writeBool(class != nil)
+		if class != nil {
+			h.writeHash(class.Hash())
+		}
+	}
+	h.writeInt(len(root.Enums))
+	for _, name := range sortedKeys(root.Enums) {
+		h.writeString(name)
+		enum := root.Enums[name]
+		h.writeBool(enum != nil)
+		if enum != nil {
+			h.writeHash(enum.Hash())
+		}
+	}
+	return h.sum()
+}
+
+// Hash returns a content hash of the class, including its members.
+func (class *Class) Hash() Hash {
+	h := newHasher()
+	h.writeString("Class")
+	h.writeString(class.Name)
+	h.writeFields(class)
+	h.writeInt(len(class.Members))
+	for _, name := range sortedKeys(class.Members) {
+		h.writeString(name)
+		member := class.Members[name]
+		h.writeBool(member != nil)
+		if member != nil {
+			h.writeHash(member.MemberHash())
+		}
+	}
+	return h.sum()
+}
+
+// hashMember returns a content hash of a member.
+func hashMember(member Member) Hash {
+	h := newHasher()
+	h.writeString(member.MemberType())
+	h.writeString(member.MemberName())
+	h.writeFields(member)
+	return h.sum()
+}
+
+// MemberHash returns a content hash of the member.
+//
+// MemberHash implements the Member interface.
+func (member *Property) MemberHash() Hash { return hashMember(member) }
+
+// MemberHash returns a content hash of the member.
+//
+// MemberHash implements the Member interface.
+func (member *Function) MemberHash() Hash { return hashMember(member) }
+
+// MemberHash returns a content hash of the member.
+//
+// MemberHash implements the Member interface.
+func (member *Event) MemberHash() Hash { return hashMember(member) }
+
+// MemberHash returns a content hash of the member.
+//
+// MemberHash implements the Member interface.
+func (member *Callback) MemberHash() Hash { return hashMember(member) }
+
+// Hash returns a content hash of the enum, including its items.
+func (enum *Enum) Hash() Hash {
+	h := newHasher()
+	h.writeString("Enum")
+	h.writeString(enum.Name)
+	h.writeFields(enum)
+	h.writeInt(len(enum.Items))
+	for _, name := range sortedKeys(enum.Items) {
+		h.writeString(name)
+		item := enum.Items[name]
+		h.writeBool(item != nil)
+		if item != nil {
+			h.writeHash(item.Hash())
+		}
+	}
+	return h.sum()
+}
+
+// Hash returns a content hash of the enum item.
+func (item *EnumItem) Hash() Hash {
+	h := newHasher()
+	h.writeString("EnumItem")
+	h.writeString(item.Name)
+	h.writeFields(item)
+	return h.sum()
+}
@@ -0,0 +1,114 @@
+package rbxdump
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CheckedFielder is implemented by a Fielder that can report the fields that
+// it could not set.
+type CheckedFielder interface {
+	Fielder
+	// SetFieldsChecked is like SetFields, but returns a FieldsError listing
+	// each field in f that is unknown, or that has a value that could not be
+	// converted to the field's type. Fields that can be set are set regardless.
+	// Returns nil if every field was set.
+	SetFieldsChecked(f Fields) error
+}
+
+// SetFields sets fields f on element e. If e implements CheckedFielder, then
+// the result of SetFieldsChecked is returned. Otherwise, SetFields is called,
+// and nil is returned.
+func SetFields(e Fielder, f Fields) error {
+	if e, ok := e.(CheckedFielder); ok {
+		return e.SetFieldsChecked(f)
+	}
+	e.SetFields(f)
+	return nil
+}
+
+// FieldError describes a field that could not be set.
+type FieldError struct {
+	// Field is the name of the field.
+	Field string
+	// Value is the value that could not be set.
+	Value any
+	// Unknown is true if the element has no field of the name. Otherwise, the
+	// value could not be converted to the field's type.
+	Unknown bool
+}
+
+// Error implements the error interface.
+func (err FieldError) Error() string {
+	if err.Unknown {
+		return "unknown field \"" + err.Field + "\""
+	}
+	return "field \"" + err.Field + "\" cannot be set to a value of this type"
+}
+
+// FieldsError is returned by SetFieldsChecked, listing each field that could
+// not be set, ordered by name.
+type FieldsError []FieldError
+
+// Error implements the error interface.
+func (err FieldsError) Error() string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(len(err)))
+	if len(err) == 1 {
+		b.WriteString(" field could not be set: ")
+	} else {
+		b.WriteString(" fields could not be set: ")
+	}
+	for i, e := range err {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(e.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns each FieldError as an error.
+func (err FieldsError) Unwrap() []error {
+	errs := make([]error, len(err))
+	for i, e := range err {
+		errs[i] = e
+	}
+	return errs
+}
+
+// fieldChecker records the result of setting each known field from a Fields.
+type fieldChecker struct {
+	fields Fields
+	known  map[string]bool
+}
+
+// check records the field of the given name as known, and whether it was set.
+// A field that is absent is not set, but is not considered to have failed.
+func (c *fieldChecker) check(name string, ok bool) {
+	if c.known == nil {
+		c.known = make(map[string]bool, len(c.fields))
+	}
+	if _, present := c.fields[name]; present {
+		c.known[name] = ok
+	} else {
+		c.known[name] = true
+	}
+}
+
+// err returns a FieldsError listing each field that is unknown or failed to
+// be set, or nil if there are none.
+func (c *fieldChecker) err() error {
+	var errs FieldsError
+	for name, value := range c.fields {
+		if ok, known := c.known[name]; !known || !ok {
+			errs = append(errs, FieldError{Field: name, Value: value, Unknown: !known})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
@@ -0,0 +1,148 @@
+package rbxdump
+
+import (
+	"slices"
+)
+
+// Predicate reports whether the element located by path satisfies a
+// condition. The element is a *Class, Member, *Enum, or *EnumItem.
+type Predicate func(path Path, element Fielder) bool
+
+// Filter returns a deep copy of the root containing only the elements for
+// which keep returns true. A class or enum that is not kept is excluded along
+// with all of its members or items. If keep is nil, then every element is
+// kept.
+//
+// References between elements, such as Superclass, are not adjusted, and may
+// refer to elements that were excluded.
+func (root *Root) Filter(keep Predicate) *Root {
+	if keep == nil {
+		return root.Copy()
+	}
+	froot := &Root{
+		Classes:    make(map[string]*Class, len(root.Classes)),
+		Enums:      make(map[string]*Enum, len(root.Enums)),
+		ClassOrder: slices.Clone(root.ClassOrder),
+		EnumOrder:  slices.Clone(root.EnumOrder),
+	}
+	for name, class := range root.Classes {
+		if class == nil || !keep(ClassPath(name), class) {
+			continue
+		}
+		fclass := *class
+		fclass.Members = make(map[string]Member, len(class.Members))
+		for mname, member := range class.Members {
+			if member == nil || !keep(Path{Element: member.MemberType(), Primary: name, Secondary: mname}, member) {
+				continue
+			}
+			fclass.Members[mname] = member.MemberCopy()
+		}
+		fclass.Tags = class.GetTags()
+		fclass.MemberOrder = slices.Clone(class.MemberOrder)
+		froot.Classes[name] = &fclass
+	}
+	for name, enum := range root.Enums {
+		if enum == nil || !keep(EnumPath(name), enum) {
+			continue
+		}
+		fenum := *enum
+		fenum.Items = make(map[string]*EnumItem, len(enum.Items))
+		for iname, item := range enum.Items {
+			if item == nil || !keep(EnumItemPath(name, iname), item) {
+				continue
+			}
+			fenum.Items[iname] = item.Copy()
+		}
+		fenum.Tags = enum.GetTags()
+		fenum.ItemOrder = slices.Clone(enum.ItemOrder)
+		froot.Enums[name] = &fenum
+	}
+	return froot
+}
+
+// Not returns a Predicate that is satisfied when p is not.
+func Not(p Predicate) Predicate {
+	return func(path Path, element Fielder) bool {
+		return !p(path, element)
+	}
+}
+
+// And returns a Predicate that is satisfied when every predicate in ps is
+// satisfied.
+func And(ps ...Predicate) Predicate {
+	return func(path Path, element Fielder) bool {
+		for _, p := range ps {
+			if !p(path, element) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Predicate that is satisfied when any predicate in ps is
+// satisfied.
+func Or(ps ...Predicate) Predicate {
+	return func(path Path, element Fielder) bool {
+		for _, p := range ps {
+			if p(path, element) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ElementIs returns a Predicate that is satisfied by elements of any of the
+// given kinds, as described by Path.Element.
+func ElementIs(kinds ...string) Predicate {
+	return func(path Path, element Fielder) bool {
+		for _, kind := range kinds {
+			if path.Element == kind {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NameMatches returns a Predicate that is satisfied by elements whose name
+// matches pattern, as in path.Match.
+func NameMatches(pattern string) Predicate {
+	return func(path Path, element Fielder) bool {
+		name := path.Secondary
+		if name == "" {
+			name = path.Primary
+		}
+		return matchName(pattern, name)
+	}
+}
+
+// HasTag returns a Predicate that is satisfied by elements that have the
+// given tag.
+func HasTag(tag string) Predicate {
+	return func(path Path, element Fielder) bool {
+		t, ok := element.(Tagger)
+		return ok && t.GetTag(tag)
+	}
+}
+
+// FieldEquals returns a Predicate that is satisfied by elements that have the
+// given field, where the field matches value. Values are compared in the same
+// way as the filters of a Query.
+func FieldEquals(field, value string) Predicate {
+	return func(path Path, element Fielder) bool {
+		v, ok := element.Fields(Fields{field: nil})[field]
+		return ok && matchValue(v, value)
+	}
+}
+
+// FieldDiffers returns a Predicate that is satisfied by elements that have
+// the given field, where the field does not match value. Values are compared
+// in the same way as the filters of a Query.
+func FieldDiffers(field, value string) Predicate {
+	return func(path Path, element Fielder) bool {
+		v, ok := element.Fields(Fields{field: nil})[field]
+		return ok && !matchValue(v, value)
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/robloxapi/rbxdump
 
-go 1.21
+go 1.23
 
 require github.com/anaminus/deep v0.0.0-20190609161759-a37cba07138a
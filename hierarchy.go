@@ -0,0 +1,232 @@
+package rbxdump
+
+import (
+	"sort"
+)
+
+// hierarchyNode represents one class within a Hierarchy.
+type hierarchyNode struct {
+	name  string
+	super *hierarchyNode
+	subs  []*hierarchyNode
+	depth int
+}
+
+// Hierarchy is a precomputed index of the inheritance tree formed by the
+// classes of a Root. The index is a snapshot; it does not reflect changes made
+// to the Root after it was created.
+//
+// A class whose Superclass is empty, NoSuperclass, missing, or part of a cycle
+// is treated as a root of the tree.
+type Hierarchy struct {
+	nodes map[string]*hierarchyNode
+	roots []*hierarchyNode
+}
+
+// NewHierarchy returns a Hierarchy indexing the classes of root.
+func NewHierarchy(root *Root) *Hierarchy {
+	h := &Hierarchy{nodes: make(map[string]*hierarchyNode, len(root.Classes))}
+	for name, class := range root.Classes {
+		if class == nil {
+			continue
+		}
+		h.nodes[name] = &hierarchyNode{name: name}
+	}
+	for name, node := range h.nodes {
+		class := root.Classes[name]
+		super := h.nodes[class.Superclass]
+		if !hasSuperclass(class) || super == nil || inCycle(root, name) {
+			h.roots = append(h.roots, node)
+			continue
+		}
+		node.super = super
+		super.subs = append(super.subs, node)
+	}
+	sort.Sort(hierarchyNodes(h.roots))
+	for _, node := range h.nodes {
+		sort.Sort(hierarchyNodes(node.subs))
+	}
+	var setDepth func(nodes []*hierarchyNode, depth int)
+	setDepth = func(nodes []*hierarchyNode, depth int) {
+		for _, node := range nodes {
+			node.depth = depth
+			setDepth(node.subs, depth+1)
+		}
+	}
+	setDepth(h.roots, 0)
+	return h
+}
+
+// inCycle returns whether following the superclass chain of the given class
+// leads back to the class.
+func inCycle(root *Root, name string) bool {
+	visited := map[string]bool{}
+	for class := root.Classes[name]; class != nil && hasSuperclass(class); {
+		if class.Superclass == name {
+			return true
+		}
+		if visited[class.Superclass] {
+			return false
+		}
+		visited[class.Superclass] = true
+		class = root.Classes[class.Superclass]
+	}
+	return false
+}
+
+// hierarchyNodes sorts nodes by name.
+type hierarchyNodes []*hierarchyNode
+
+func (a hierarchyNodes) Len() int           { return len(a) }
+func (a hierarchyNodes) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a hierarchyNodes) Less(i, j int) bool { return a[i].name < a[j].name }
+
+// names returns the names of nodes.
+func names(nodes []*hierarchyNode) []string {
+	list := make([]string, len(nodes))
+	for i, node := range nodes {
+		list[i] = node.name
+	}
+	return list
+}
+
+// Has returns whether the hierarchy contains the given class.
+func (h *Hierarchy) Has(class string) bool {
+	return h.nodes[class] != nil
+}
+
+// Superclass returns the name of the superclass of the given class within the
+// hierarchy. Returns an empty string if the class is a root, or does not
+// exist.
+func (h *Hierarchy) Superclass(class string) string {
+	if node := h.nodes[class]; node != nil && node.super != nil {
+		return node.super.name
+	}
+	return ""
+}
+
+// Depth returns the number of ancestors of the given class. Returns -1 if the
+// class does not exist.
+func (h *Hierarchy) Depth(class string) int {
+	if node := h.nodes[class]; node != nil {
+		return node.depth
+	}
+	return -1
+}
+
+// IsA returns whether class is ancestor, or inherits from ancestor. Returns
+// false if either class does not exist.
+func (h *Hierarchy) IsA(class, ancestor string) bool {
+	node := h.nodes[class]
+	target := h.nodes[ancestor]
+	if node == nil || target == nil {
+		return false
+	}
+	for ; node != nil; node = node.super {
+		if node == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Ancestors returns the names of the superclasses of the given class, ordered
+// from nearest to furthest.
+func (h *Hierarchy) Ancestors(class string) []string {
+	node := h.nodes[class]
+	if node == nil {
+		return nil
+	}
+	list := make([]string, 0, node.depth)
+	for node = node.super; node != nil; node = node.super {
+		list = append(list, node.name)
+	}
+	return list
+}
+
+// Subclasses returns the names of the classes that directly inherit from the
+// given class, ordered by name.
+func (h *Hierarchy) Subclasses(class string) []string {
+	node := h.nodes[class]
+	if node == nil {
+		return nil
+	}
+	return names(node.subs)
+}
+
+// walk traverses nodes depth-first, calling fn for each node.
+func walk(nodes []*hierarchyNode, fn func(node *hierarchyNode)) {
+	for _, node := range nodes {
+		fn(node)
+		walk(node.subs, fn)
+	}
+}
+
+// Descendants returns the names of every class that inherits from the given
+// class, directly or indirectly. The result is ordered by traversing the tree
+// depth-first, with siblings ordered by name.
+func (h *Hierarchy) Descendants(class string) []string {
+	node := h.nodes[class]
+	if node == nil {
+		return nil
+	}
+	var list []string
+	walk(node.subs, func(node *hierarchyNode) {
+		list = append(list, node.name)
+	})
+	return list
+}
+
+// CommonAncestor returns the nearest class that both a and b are or inherit
+// from. Returns an empty string if there is no such class.
+func (h *Hierarchy) CommonAncestor(a, b string) string {
+	na := h.nodes[a]
+	nb := h.nodes[b]
+	if na == nil || nb == nil {
+		return ""
+	}
+	for na.depth > nb.depth {
+		na = na.super
+	}
+	for nb.depth > na.depth {
+		nb = nb.super
+	}
+	for na != nb {
+		if na.super == nil || nb.super == nil {
+			return ""
+		}
+		na = na.super
+		nb = nb.super
+	}
+	return na.name
+}
+
+// Roots returns the names of classes that have no superclass within the
+// hierarchy, ordered by name.
+func (h *Hierarchy) Roots() []string {
+	return names(h.roots)
+}
+
+// Leaves returns the names of classes that have no subclasses, ordered by
+// name.
+func (h *Hierarchy) Leaves() []string {
+	var list []string
+	for name, node := range h.nodes {
+		if len(node.subs) == 0 {
+			list = append(list, name)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Order returns the names of all classes, ordered by traversing the tree
+// depth-first, with siblings ordered by name. Each class is preceded by its
+// superclass.
+func (h *Hierarchy) Order() []string {
+	list := make([]string, 0, len(h.nodes))
+	walk(h.roots, func(node *hierarchyNode) {
+		list = append(list, node.name)
+	})
+	return list
+}
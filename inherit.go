@@ -0,0 +1,152 @@
+package rbxdump
+
+import (
+	"sort"
+)
+
+// NoSuperclass is the Superclass used by API dumps to indicate that a class
+// has no superclass. An empty Superclass is treated the same way.
+const NoSuperclass = "<<<ROOT>>>"
+
+// hasSuperclass returns whether class refers to a superclass.
+func hasSuperclass(class *Class) bool {
+	return class.Superclass != "" && class.Superclass != NoSuperclass
+}
+
+// SuperclassError indicates that the superclass chain of a class could not be
+// resolved.
+type SuperclassError interface {
+	error
+	// SuperclassError returns the name of the class whose Superclass could not
+	// be resolved, and the Superclass itself. cycle is true if the superclass
+	// refers back to a class already in the chain, and false if the superclass
+	// does not exist.
+	SuperclassError() (class, superclass string, cycle bool)
+}
+
+// errSuperclass implements the SuperclassError interface.
+type errSuperclass struct {
+	class      string
+	superclass string
+	cycle      bool
+}
+
+func (err errSuperclass) Error() string {
+	if err.cycle {
+		return "superclass \"" + err.superclass + "\" of class \"" + err.class + "\" forms a cycle"
+	}
+	return "superclass \"" + err.superclass + "\" of class \"" + err.class + "\" does not exist"
+}
+
+func (err errSuperclass) SuperclassError() (class, superclass string, cycle bool) {
+	return err.class, err.superclass, err.cycle
+}
+
+// ancestry walks the superclass chain of class, calling fn for each class in
+// the chain, starting with class itself. Stops early if fn returns false.
+// Returns a SuperclassError if the chain is broken or cyclic.
+func (root *Root) ancestry(class *Class, fn func(class *Class) bool) error {
+	visited := map[string]bool{}
+	for {
+		visited[class.Name] = true
+		if !fn(class) {
+			return nil
+		}
+		if !hasSuperclass(class) {
+			return nil
+		}
+		if visited[class.Superclass] {
+			return errSuperclass{class: class.Name, superclass: class.Superclass, cycle: true}
+		}
+		super, ok := root.Classes[class.Superclass]
+		if !ok || super == nil {
+			return errSuperclass{class: class.Name, superclass: class.Superclass}
+		}
+		class = super
+	}
+}
+
+// GetAncestry returns the class of the given name followed by each of its
+// superclasses, ordered from nearest to furthest. Returns nil if the class does
+// not exist.
+//
+// If the superclass chain is broken or cyclic, then the classes resolved so far
+// are returned along with a SuperclassError.
+func (root *Root) GetAncestry(class string) (list []*Class, err error) {
+	c := root.Classes[class]
+	if c == nil {
+		return nil, nil
+	}
+	err = root.ancestry(c, func(class *Class) bool {
+		list = append(list, class)
+		return true
+	})
+	return list, err
+}
+
+// LookupMember resolves a member of the given name through the class of the
+// given name, and then through each of its superclasses. Returns the member
+// along with the class that declares it. Returns nil values if the member could
+// not be found.
+//
+// If the superclass chain is broken or cyclic before the member is found, then
+// a SuperclassError is returned.
+func (root *Root) LookupMember(class, member string) (Member, *Class, error) {
+	c := root.Classes[class]
+	if c == nil {
+		return nil, nil, nil
+	}
+	var m Member
+	var decl *Class
+	err := root.ancestry(c, func(class *Class) bool {
+		if m = class.Members[member]; m != nil {
+			decl = class
+			return false
+		}
+		return true
+	})
+	return m, decl, err
+}
+
+// ClassMember associates a Member with the Class that declares it.
+type ClassMember struct {
+	Class  *Class
+	Member Member
+}
+
+// sortClassMembers sorts ClassMember values by member name.
+type sortClassMembers []ClassMember
+
+func (a sortClassMembers) Len() int      { return len(a) }
+func (a sortClassMembers) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a sortClassMembers) Less(i, j int) bool {
+	return a[i].Member.MemberName() < a[j].Member.MemberName()
+}
+
+// GetAllMembers returns every member accessible through the class of the given
+// name, including members inherited from superclasses, sorted by name. When a
+// member is declared by more than one class in the chain, only the member of
+// the nearest class is included. Returns nil if the class does not exist.
+//
+// If the superclass chain is broken or cyclic, then the members resolved so far
+// are returned along with a SuperclassError.
+func (root *Root) GetAllMembers(class string) ([]ClassMember, error) {
+	c := root.Classes[class]
+	if c == nil {
+		return nil, nil
+	}
+	seen := map[string]bool{}
+	var list []ClassMember
+	err := root.ancestry(c, func(class *Class) bool {
+		for name, member := range class.Members {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			list = append(list, ClassMember{Class: class, Member: member})
+		}
+		return true
+	})
+	sort.Sort(sortClassMembers(list))
+	return list, err
+}
@@ -0,0 +1,216 @@
+package rbxdump
+
+import (
+	"iter"
+	"slices"
+	"sort"
+)
+
+// IterClasses returns an iterator over the classes of the root. If ClassOrder
+// names each class exactly once, as for a root decoded with RetainOrder, then
+// classes are visited in that order, without allocating or sorting. Otherwise,
+// classes are ordered by name, and each iteration allocates and sorts a list
+// of names. Nil classes are skipped. The order is determined when iteration
+// begins; classes added or removed during iteration may or may not be
+// visited.
+func (root *Root) IterClasses() iter.Seq[*Class] {
+	return func(yield func(*Class) bool) {
+		for _, name := range orderedKeys(root.ClassOrder, root.Classes) {
+			if class := root.Classes[name]; class != nil && !yield(class) {
+				return
+			}
+		}
+	}
+}
+
+// IterEnums returns an iterator over the enums of the root. If EnumOrder names
+// each enum exactly once, then enums are visited in that order, without
+// allocating or sorting. Otherwise, enums are ordered by name, and each
+// iteration allocates and sorts a list of names. Nil enums are skipped. The
+// order is determined when iteration begins; enums added or removed during
+// iteration may or may not be visited.
+func (root *Root) IterEnums() iter.Seq[*Enum] {
+	return func(yield func(*Enum) bool) {
+		for _, name := range orderedKeys(root.EnumOrder, root.Enums) {
+			if enum := root.Enums[name]; enum != nil && !yield(enum) {
+				return
+			}
+		}
+	}
+}
+
+// IterMembers returns an iterator over the members of the class. If any member
+// types are given, then only members whose MemberType is one of the types are
+// visited. Nil members are skipped.
+//
+// If MemberOrder names each member exactly once, then members are visited in
+// that order, without allocating or sorting. Otherwise, members are ordered by
+// name, and each iteration allocates and sorts a list of names.
+func (class *Class) IterMembers(memberTypes ...string) iter.Seq[Member] {
+	return func(yield func(Member) bool) {
+		for _, name := range orderedKeys(class.MemberOrder, class.Members) {
+			member := class.Members[name]
+			if !matchMember(member, memberTypes) {
+				continue
+			}
+			if !yield(member) {
+				return
+			}
+		}
+	}
+}
+
+// matchMember returns whether member is not nil, and has one of the given
+// member types, if any are given.
+func matchMember(member Member, memberTypes []string) bool {
+	if member == nil {
+		return false
+	}
+	return len(memberTypes) == 0 || slices.Contains(memberTypes, member.MemberType())
+}
+
+// IterEnumItems returns an iterator over the items of the enum. If ItemOrder
+// names each item exactly once, then items are visited in that order, without
+// allocating or sorting. Otherwise, items are visited in the same order as
+// GetEnumItems, and each iteration allocates and sorts a list of items. Nil
+// items are skipped.
+func (enum *Enum) IterEnumItems() iter.Seq[*EnumItem] {
+	return func(yield func(*EnumItem) bool) {
+		if listsKeys(enum.ItemOrder, enum.Items) {
+			for _, name := range enum.ItemOrder {
+				if item := enum.Items[name]; item != nil && !yield(item) {
+					return
+				}
+			}
+			return
+		}
+		items := make([]*EnumItem, 0, len(enum.Items))
+		for _, item := range enum.Items {
+			if item != nil {
+				items = append(items, item)
+			}
+		}
+		sort.Sort(sortEnumItems(items))
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// IterClassesUnordered is like IterClasses, but visits classes in an
+// unspecified order, which may differ between iterations. Does not allocate or
+// sort.
+func (root *Root) IterClassesUnordered() iter.Seq[*Class] {
+	return func(yield func(*Class) bool) {
+		for _, class := range root.Classes {
+			if class != nil && !yield(class) {
+				return
+			}
+		}
+	}
+}
+
+// IterEnumsUnordered is like IterEnums, but visits enums in an unspecified
+// order, which may differ between iterations. Does not allocate or sort.
+func (root *Root) IterEnumsUnordered() iter.Seq[*Enum] {
+	return func(yield func(*Enum) bool) {
+		for _, enum := range root.Enums {
+			if enum != nil && !yield(enum) {
+				return
+			}
+		}
+	}
+}
+
+// IterMembersUnordered is like IterMembers, but visits members in an
+// unspecified order, which may differ between iterations. Does not allocate
+// or sort.
+func (class *Class) IterMembersUnordered(memberTypes ...string) iter.Seq[Member] {
+	return func(yield func(Member) bool) {
+		for _, member := range class.Members {
+			if matchMember(member, memberTypes) && !yield(member) {
+				return
+			}
+		}
+	}
+}
+
+// IterEnumItemsUnordered is like IterEnumItems, but visits items in an
+// unspecified order, which may differ between iterations. Does not allocate
+// or sort.
+func (enum *Enum) IterEnumItemsUnordered() iter.Seq[*EnumItem] {
+	return func(yield func(*EnumItem) bool) {
+		for _, item := range enum.Items {
+			if item != nil && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Walk returns an iterator over every element of the root, paired with the
+// path locating it. Each class is followed by its members, and each enum is
+// followed by its items. Classes are visited before enums. The order of each
+// kind of element is the same as IterClasses, IterMembers, IterEnums, and
+// IterEnumItems.
+func (root *Root) Walk() iter.Seq2[Path, Fielder] {
+	return func(yield func(Path, Fielder) bool) {
+		for class := range root.IterClasses() {
+			if !yield(ClassPath(class.Name), class) {
+				return
+			}
+			for member := range class.IterMembers() {
+				if !yield(MemberPath(class.Name, member), member) {
+					return
+				}
+			}
+		}
+		for enum := range root.IterEnums() {
+			if !yield(EnumPath(enum.Name), enum) {
+				return
+			}
+			for item := range enum.IterEnumItems() {
+				if !yield(EnumItemPath(enum.Name, item.Name), item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Visitor is implemented by any value that visits the elements of a Root.
+type Visitor interface {
+	// VisitClass is called for each class. If false is returned, then the
+	// members of the class are not visited.
+	VisitClass(class *Class) bool
+	// VisitMember is called for each member of a visited class.
+	VisitMember(class *Class, member Member)
+	// VisitEnum is called for each enum. If false is returned, then the items
+	// of the enum are not visited.
+	VisitEnum(enum *Enum) bool
+	// VisitEnumItem is called for each item of a visited enum.
+	VisitEnumItem(enum *Enum, item *EnumItem)
+}
+
+// Visit calls the methods of v for each element of the root, in the same
+// order as Walk.
+func (root *Root) Visit(v Visitor) {
+	for class := range root.IterClasses() {
+		if !v.VisitClass(class) {
+			continue
+		}
+		for member := range class.IterMembers() {
+			v.VisitMember(class, member)
+		}
+	}
+	for enum := range root.IterEnums() {
+		if !v.VisitEnum(enum) {
+			continue
+		}
+		for item := range enum.IterEnumItems() {
+			v.VisitEnumItem(enum, item)
+		}
+	}
+}
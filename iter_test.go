@@ -0,0 +1,77 @@
+package rbxdump
+
+import (
+	"slices"
+	"testing"
+)
+
+func iterRoot(order bool) *Root {
+	root := &Root{
+		Classes: map[string]*Class{
+			"B": {Name: "B", Members: map[string]Member{
+				"Z": &Property{Name: "Z"},
+				"A": &Function{Name: "A"},
+			}},
+			"A": {Name: "A"},
+		},
+		Enums: map[string]*Enum{
+			"E": {Name: "E", Items: map[string]*EnumItem{
+				"Zed":   {Name: "Zed", Index: 1},
+				"Alpha": {Name: "Alpha", Index: 0},
+			}},
+		},
+	}
+	if order {
+		root.ClassOrder = []string{"B", "A"}
+		root.Classes["B"].MemberOrder = []string{"Z", "A"}
+		root.EnumOrder = []string{"E"}
+		root.Enums["E"].ItemOrder = []string{"Zed", "Alpha"}
+	}
+	return root
+}
+
+func walkNames(root *Root) (names []string) {
+	for path := range root.Walk() {
+		names = append(names, path.String())
+	}
+	return names
+}
+
+func TestIterOrder(t *testing.T) {
+	// Incomplete order fields are ignored.
+	partial := iterRoot(true)
+	partial.ClassOrder = []string{"B"}
+	partial.Classes["B"].MemberOrder = []string{"Z", "Z"}
+
+	tests := []struct {
+		name string
+		root *Root
+		want []string
+	}{
+		{"ByName", iterRoot(false), []string{"Class A", "Class B", "Function B.A", "Property B.Z", "Enum E", "EnumItem E.Alpha", "EnumItem E.Zed"}},
+		{"Listed", iterRoot(true), []string{"Class B", "Property B.Z", "Function B.A", "Class A", "Enum E", "EnumItem E.Zed", "EnumItem E.Alpha"}},
+		{"Incomplete", partial, []string{"Class A", "Class B", "Function B.A", "Property B.Z", "Enum E", "EnumItem E.Zed", "EnumItem E.Alpha"}},
+	}
+	for _, test := range tests {
+		if got := walkNames(test.root); !slices.Equal(got, test.want) {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestIterListedDoesNotAllocate(t *testing.T) {
+	root := iterRoot(true)
+	allocs := testing.AllocsPerRun(100, func() {
+		for class := range root.IterClasses() {
+			for range class.IterMembers() {
+			}
+		}
+		for enum := range root.IterEnums() {
+			for range enum.IterEnumItems() {
+			}
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations, want 0", allocs)
+	}
+}
@@ -36,8 +36,14 @@ func (root *jRoot) UnmarshalJSON(b []byte) (err error) {
 			}
 			for _, jmember := range jclass.Members {
 				class.Members[jmember.MemberName()] = jmember.Member
+				if root.retainOrder {
+					class.MemberOrder = append(class.MemberOrder, jmember.MemberName())
+				}
 			}
 			root.Classes[class.Name] = &class
+			if root.retainOrder {
+				root.ClassOrder = append(root.ClassOrder, class.Name)
+			}
 		}
 
 		root.Enums = make(map[string]*rbxdump.Enum, len(r.Enums))
@@ -59,8 +65,14 @@ func (root *jRoot) UnmarshalJSON(b []byte) (err error) {
 					Tags:                tags,
 					LegacyNames:         jitem.LegacyNames,
 				}
+				if root.retainOrder {
+					enum.ItemOrder = append(enum.ItemOrder, jitem.Name)
+				}
 			}
 			root.Enums[enum.Name] = &enum
+			if root.retainOrder {
+				root.EnumOrder = append(root.EnumOrder, enum.Name)
+			}
 		}
 	default:
 		return errVersion(v.Version)
@@ -80,10 +92,15 @@ func (jmember *jMember) UnmarshalJSON(b []byte) (err error) {
 			return err
 		}
 		tags, pd := unmarshalTags(member.Tags)
+		var def string
+		if member.Default != nil {
+			def = *member.Default
+		}
 		jmember.Member = &rbxdump.Property{
 			Name:                member.Name,
 			ValueType:           rbxdump.Type(member.ValueType),
-			Default:             member.Default,
+			Default:             def,
+			NoDefault:           member.Default == nil,
 			Category:            member.Category,
 			ReadSecurity:        member.Security.Read,
 			WriteSecurity:       member.Security.Write,
@@ -178,11 +195,25 @@ func (param *jParameter) UnmarshalJSON(b []byte) (err error) {
 	return nil
 }
 
+// Decoder decodes an API dump in JSON format.
+type Decoder struct {
+	// RetainOrder indicates whether the order in which classes, members,
+	// enums, and enum items appear is recorded in the ClassOrder, MemberOrder,
+	// EnumOrder, and ItemOrder fields of the decoded root. The order of enum
+	// items is also always recorded in the Index field of each item.
+	RetainOrder bool
+}
+
 // Decode parses an API dump from r in JSON format.
-func Decode(r io.Reader) (root *rbxdump.Root, err error) {
-	jroot := &jRoot{}
+func (d Decoder) Decode(r io.Reader) (root *rbxdump.Root, err error) {
+	jroot := &jRoot{retainOrder: d.RetainOrder}
 	if err = json.NewDecoder(r).Decode(jroot); err != nil {
 		return nil, err
 	}
 	return &jroot.Root, nil
 }
+
+// Decode parses an API dump from r in JSON format.
+func Decode(r io.Reader) (root *rbxdump.Root, err error) {
+	return Decoder{}.Decode(r)
+}
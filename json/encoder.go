@@ -8,35 +8,6 @@ import (
 	"github.com/robloxapi/rbxdump"
 )
 
-// treeNode represents one node of a class inheritance tree.
-type treeNode struct {
-	name  string
-	sup   string
-	subs  treeNodes
-	index int
-}
-
-// treeNodes sorts by name.
-type treeNodes []*treeNode
-
-func (a treeNodes) Len() int           { return len(a) }
-func (a treeNodes) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a treeNodes) Less(i, j int) bool { return a[i].name < a[j].name }
-
-// setTreeIndex recursively traverses treeNodes depth-first, setting each index
-// field.
-func setTreeIndex(classes []jClass, nodes treeNodes, i int) int {
-	for _, node := range nodes {
-		classes[node.index].index = i
-		if len(node.subs) > 0 {
-			i = setTreeIndex(classes, node.subs, i+1)
-		} else {
-			i++
-		}
-	}
-	return i
-}
-
 // jClasses sorts classes by index.
 type jClasses []jClass
 
@@ -44,32 +15,25 @@ func (a jClasses) Len() int           { return len(a) }
 func (a jClasses) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a jClasses) Less(i, j int) bool { return a[i].index < a[j].index }
 
-// Sorts the list as an inheritance tree traversed depth-first.
-func sortByInheritance(classes []jClass) {
-	nodes := make(map[string]*treeNode, len(classes))
-	for i, class := range classes {
-		nodes[class.Name] = &treeNode{
-			name:  class.Name,
-			sup:   class.Superclass,
-			index: i,
-		}
+// Sorts the list as an inheritance tree traversed depth-first. If retainOrder
+// is true, then classes listed in the root's ClassOrder are sorted first, in
+// the listed order.
+func sortByInheritance(root *rbxdump.Root, classes []jClass, retainOrder bool) {
+	var listed map[string]int
+	if retainOrder {
+		listed = root.ClassOrderIndex()
 	}
-
-	top := treeNodes{}
-	for _, node := range nodes {
-		if sup := nodes[node.sup]; sup != nil {
-			sup.subs = append(sup.subs, node)
-		} else {
-			top = append(top, node)
-		}
+	order := rbxdump.NewHierarchy(root).Order()
+	index := make(map[string]int, len(order))
+	for i, name := range order {
+		index[name] = len(listed) + i
 	}
-
-	sort.Sort(top)
-	for _, node := range nodes {
-		sort.Sort(node.subs)
+	for name, i := range listed {
+		index[name] = i
+	}
+	for i := range classes {
+		classes[i].index = index[classes[i].Name]
 	}
-
-	setTreeIndex(classes, top, 0)
 	sort.Sort(jClasses(classes))
 }
 
@@ -88,12 +52,19 @@ func memberTypeOrder(member rbxdump.Member) int {
 	return -1
 }
 
-// jMembers sorts members by member type, then by name.
+// jMembers sorts listed members by index, followed by unlisted members sorted
+// by member type, then by name.
 type jMembers []jMember
 
 func (a jMembers) Len() int      { return len(a) }
 func (a jMembers) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a jMembers) Less(i, j int) bool {
+	if a[i].index >= 0 || a[j].index >= 0 {
+		if a[i].index < 0 || a[j].index < 0 {
+			return a[i].index >= 0
+		}
+		return a[i].index < a[j].index
+	}
 	ti := memberTypeOrder(a[i].Member)
 	tj := memberTypeOrder(a[j].Member)
 	if ti == tj {
@@ -105,19 +76,44 @@ func (a jMembers) Less(i, j int) bool {
 	return ti < tj
 }
 
-// jEnums sorts enums by name.
+// jEnums sorts listed enums by index, followed by unlisted enums sorted by
+// name.
 type jEnums []jEnum
 
-func (a jEnums) Len() int           { return len(a) }
-func (a jEnums) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a jEnums) Less(i, j int) bool { return a[i].Name < a[j].Name }
+func (a jEnums) Len() int      { return len(a) }
+func (a jEnums) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a jEnums) Less(i, j int) bool {
+	if a[i].index >= 0 || a[j].index >= 0 {
+		if a[i].index < 0 || a[j].index < 0 {
+			return a[i].index >= 0
+		}
+		return a[i].index < a[j].index
+	}
+	return a[i].Name < a[j].Name
+}
+
+// listedIndex returns the position of name in listed, or -1 if it is not
+// listed.
+func listedIndex(listed map[string]int, name string) int {
+	if i, ok := listed[name]; ok {
+		return i
+	}
+	return -1
+}
 
-// jEnumItems sorts enum items by index, then name, then value.
+// jEnumItems sorts listed enum items by position, followed by unlisted items
+// sorted by index, then name, then value.
 type jEnumItems []jEnumItem
 
 func (a jEnumItems) Len() int      { return len(a) }
 func (a jEnumItems) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a jEnumItems) Less(i, j int) bool {
+	if a[i].listed >= 0 || a[j].listed >= 0 {
+		if a[i].listed < 0 || a[j].listed < 0 {
+			return a[i].listed >= 0
+		}
+		return a[i].listed < a[j].listed
+	}
 	if a[i].index == a[j].index {
 		if a[i].Name == a[j].Name {
 			return a[i].Value < a[j].Value
@@ -135,7 +131,7 @@ func marshalTags(tags []string, pd rbxdump.PreferredDescriptor) (jtags []jTag) {
 	jtags = make([]jTag, 0, n)
 	for _, tag := range tags {
 		jtags = append(jtags, jTag{Tag: tag})
-		if tag == "Deprecated" && pd != (rbxdump.PreferredDescriptor{}) {
+		if tag == rbxdump.TagDeprecated && pd != (rbxdump.PreferredDescriptor{}) {
 			p := jPreferredDescriptor{
 				PreferredDescriptorName: pd.Name,
 				ThreadSafety:            pd.ThreadSafety,
@@ -146,21 +142,28 @@ func marshalTags(tags []string, pd rbxdump.PreferredDescriptor) (jtags []jTag) {
 	return jtags
 }
 
+// jOrderedRoot has the field order of the official format.
+type jOrderedRoot struct {
+	Classes []jClass
+	Enums   []jEnum
+	Version int
+}
+
 func (root jRoot) MarshalJSON() (b []byte, err error) {
-	var r struct {
-		Version int
-		Classes []jClass
-		Enums   []jEnum
-	}
+	var r jOrderedRoot
 	r.Version = 1
 
 	r.Classes = make([]jClass, 0, len(root.Classes))
 	for _, class := range root.Classes {
+		var listed map[string]int
+		if root.retainOrder {
+			listed = class.MemberOrderIndex()
+		}
 		members := make([]jMember, 0, len(class.Members))
-		for _, member := range class.Members {
-			jmember := jMember{Member: member}
+		for name, member := range class.Members {
+			jmember := jMember{Member: member, index: listedIndex(listed, name), retainOrder: root.retainOrder}
 			if f, ok := member.(*rbxdump.Function); ok {
-				if f.GetTag("Yields") {
+				if f.Yields() {
 					jmember.yields = 1
 				}
 			}
@@ -175,18 +178,27 @@ func (root jRoot) MarshalJSON() (b []byte, err error) {
 			Tags:           marshalTags(class.Tags, class.PreferredDescriptor),
 		})
 	}
-	sortByInheritance(r.Classes)
+	sortByInheritance(&root.Root, r.Classes, root.retainOrder)
 
+	var listed map[string]int
+	if root.retainOrder {
+		listed = root.EnumOrderIndex()
+	}
 	r.Enums = make([]jEnum, 0, len(root.Enums))
-	for _, enum := range root.Enums {
+	for name, enum := range root.Enums {
+		var itemsListed map[string]int
+		if root.retainOrder {
+			itemsListed = enum.ItemOrderIndex()
+		}
 		items := make([]jEnumItem, 0, len(enum.Items))
-		for _, item := range enum.Items {
+		for iname, item := range enum.Items {
 			items = append(items, jEnumItem{
 				Name:        item.Name,
 				Value:       item.Value,
 				Tags:        marshalTags(item.Tags, item.PreferredDescriptor),
 				LegacyNames: item.LegacyNames,
 				index:       item.Index,
+				listed:      listedIndex(itemsListed, iname),
 			})
 		}
 		sort.Sort(jEnumItems(items))
@@ -194,22 +206,31 @@ func (root jRoot) MarshalJSON() (b []byte, err error) {
 			Name:  enum.Name,
 			Items: items,
 			Tags:  marshalTags(enum.Tags, enum.PreferredDescriptor),
+			index: listedIndex(listed, name),
 		})
 	}
 	sort.Sort(jEnums(r.Enums))
 
-	return json.Marshal(&r)
+	if root.retainOrder {
+		return marshal(&r)
+	}
+	// Version is written first, and HTML characters are escaped.
+	return json.Marshal(&struct {
+		Version int
+		Classes []jClass
+		Enums   []jEnum
+	}{r.Version, r.Classes, r.Enums})
 }
 
 func (member jMember) MarshalJSON() (b []byte, err error) {
 	var jmember any
+	retainOrder := member.retainOrder
 	switch member := member.Member.(type) {
 	case *rbxdump.Property:
 		m := jProperty{
 			MemberType:   "Property",
 			Name:         member.Name,
 			ValueType:    jType(member.ValueType),
-			Default:      member.Default,
 			Category:     member.Category,
 			ThreadSafety: member.ThreadSafety,
 			Tags:         marshalTags(member.Tags, member.PreferredDescriptor),
@@ -218,6 +239,9 @@ func (member jMember) MarshalJSON() (b []byte, err error) {
 		m.Security.Write = member.WriteSecurity
 		m.Serialization.CanLoad = member.CanLoad
 		m.Serialization.CanSave = member.CanSave
+		if !retainOrder || !member.NoDefault {
+			m.Default = &member.Default
+		}
 		jmember = m
 	case *rbxdump.Function:
 		params := make([]jParameter, len(member.Parameters))
@@ -264,7 +288,7 @@ func (member jMember) MarshalJSON() (b []byte, err error) {
 		}
 		jmember = m
 	}
-	return json.Marshal(&jmember)
+	return marshal(&jmember)
 }
 
 func (param jParameter) MarshalJSON() (b []byte, err error) {
@@ -278,13 +302,33 @@ func (param jParameter) MarshalJSON() (b []byte, err error) {
 	if param.Optional {
 		p.Default = &param.Default
 	}
-	return json.Marshal(&p)
+	return marshal(&p)
+}
+
+// Encoder encodes an API dump in JSON format.
+type Encoder struct {
+	// RetainOrder indicates whether classes, members, enums, and enum items
+	// are written in the order recorded by the ClassOrder, MemberOrder,
+	// EnumOrder, and ItemOrder fields of the root, so that a root decoded with
+	// Decoder.RetainOrder is encoded exactly as the original. In this case,
+	// Version is written after Classes and Enums, HTML characters are not
+	// escaped, matching the official format, and the Default of a property is
+	// omitted if the property has NoDefault set.
+	//
+	// Otherwise, the order fields are ignored, Version is written first, HTML
+	// characters are escaped, and every property has a Default, as by Encode.
+	RetainOrder bool
 }
 
 // Encode encodes root, writing the results to w in the API dump JSON format.
-func Encode(w io.Writer, root *rbxdump.Root) (err error) {
+func (e Encoder) Encode(w io.Writer, root *rbxdump.Root) (err error) {
 	je := json.NewEncoder(w)
 	je.SetIndent("", "\t")
 	je.SetEscapeHTML(false)
-	return je.Encode(&jRoot{*root})
+	return je.Encode(&jRoot{Root: *root, retainOrder: e.RetainOrder})
+}
+
+// Encode encodes root, writing the results to w in the API dump JSON format.
+func Encode(w io.Writer, root *rbxdump.Root) (err error) {
+	return Encoder{}.Encode(w, root)
 }
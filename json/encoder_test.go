@@ -0,0 +1,50 @@
+package json
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/robloxapi/rbxdump"
+)
+
+func TestRetainOrderRoundTrip(t *testing.T) {
+	src, err := os.ReadFile("testdata/API-Dump.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := Decoder{RetainOrder: true}.Decode(bytes.NewReader(src))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	instance := root.Classes["Instance"]
+	for name, want := range map[string]bool{"Archivable": true, "Name": false} {
+		if p := instance.Members[name].(*rbxdump.Property); p.NoDefault != want {
+			t.Errorf("Instance.%s: NoDefault is %v, want %v", name, p.NoDefault, want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := (Encoder{RetainOrder: true}).Encode(&buf, root); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if got := buf.Bytes(); !bytes.Equal(got, src) {
+		t.Errorf("round trip differs from source\ngot:\n%s", got)
+	}
+}
+
+func TestEncodeWritesDefault(t *testing.T) {
+	root := &rbxdump.Root{Classes: map[string]*rbxdump.Class{
+		"Instance": {Name: "Instance", Members: map[string]rbxdump.Member{
+			"Name": &rbxdump.Property{Name: "Name", NoDefault: true},
+		}},
+	}}
+	var buf bytes.Buffer
+	if err := Encode(&buf, root); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"Default": ""`)) {
+		t.Errorf("Default not written without RetainOrder:\n%s", buf.Bytes())
+	}
+}
@@ -3,6 +3,7 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
 	"strconv"
 	"strings"
@@ -25,8 +26,22 @@ func (err errVersion) Error() string {
 	return "version " + strconv.FormatInt(int64(err), 10) + " is unsupported"
 }
 
+// marshal is like json.Marshal, but does not escape HTML characters, matching
+// the official format. When a root is encoded without retaining order, such
+// characters are escaped by json.Marshal as it compacts the nested output.
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	je := json.NewEncoder(&buf)
+	je.SetEscapeHTML(false)
+	if err := je.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
 type jRoot struct {
 	rbxdump.Root
+	retainOrder bool
 }
 
 func unmarshalTags(jtags []jTag) (tags []string, pd rbxdump.PreferredDescriptor) {
@@ -57,6 +72,9 @@ type jClass struct {
 type jMember struct {
 	rbxdump.Member
 	yields int // Used to sort YieldFunctions after Functions.
+	index  int // Position within MemberOrder, or -1 if unlisted.
+
+	retainOrder bool
 }
 
 type jProperty struct {
@@ -68,7 +86,7 @@ type jProperty struct {
 	ThreadSafety  string `json:",omitempty"`
 	Tags          []jTag `json:",omitempty"`
 	ValueType     jType
-	Default       string
+	Default       *string `json:",omitempty"`
 }
 
 type jReturnType []rbxdump.Type
@@ -93,13 +111,13 @@ func (t *jReturnType) UnmarshalJSON(b []byte) error {
 
 func (t jReturnType) MarshalJSON() ([]byte, error) {
 	if len(t) == 1 {
-		return json.Marshal(jType((t)[0]))
+		return marshal(jType((t)[0]))
 	}
 	array := make([]jType, len(t))
 	for i, v := range t {
 		array[i] = jType(v)
 	}
-	return json.Marshal(array)
+	return marshal(array)
 }
 
 type jFunction struct {
@@ -135,6 +153,8 @@ type jEnum struct {
 	Items []jEnumItem
 	Name  string
 	Tags  []jTag `json:",omitempty"`
+
+	index int
 }
 
 type jEnumItem struct {
@@ -143,7 +163,8 @@ type jEnumItem struct {
 	LegacyNames []string `json:",omitempty"`
 	Value       int
 
-	index int
+	index  int
+	listed int // Position within ItemOrder, or -1 if unlisted.
 }
 
 type jParameter rbxdump.Parameter
@@ -167,7 +188,7 @@ func (t jType) MarshalJSON() (b []byte, err error) {
 	if t.Optional {
 		jt.Name += "?"
 	}
-	return json.Marshal(jt)
+	return marshal(jt)
 }
 
 func (t *jType) UnmarshalJSON(b []byte) error {
@@ -208,9 +229,9 @@ func (tag *jTag) UnmarshalJSON(b []byte) (err error) {
 
 func (tag *jTag) MarshalJSON() (b []byte, err error) {
 	if tag.Preferred != nil {
-		return json.Marshal(*tag.Preferred)
+		return marshal(*tag.Preferred)
 	}
-	return json.Marshal(tag.Tag)
+	return marshal(tag.Tag)
 }
 
 type jPreferredDescriptor struct {
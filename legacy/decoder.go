@@ -39,6 +39,8 @@ type decoder struct {
 	line  int
 	class *rbxdump.Class
 	enum  *rbxdump.Enum
+
+	retainOrder bool
 }
 
 // Creates a syntaxError with the current line number.
@@ -250,6 +252,9 @@ func (d *decoder) addClass(class *rbxdump.Class) {
 		return
 	}
 	d.root.Classes[class.Name] = class
+	if d.retainOrder {
+		d.root.ClassOrder = append(d.root.ClassOrder, class.Name)
+	}
 	d.class = class
 }
 
@@ -259,6 +264,9 @@ func (d *decoder) addEnum(enum *rbxdump.Enum) {
 		return
 	}
 	d.root.Enums[enum.Name] = enum
+	if d.retainOrder {
+		d.root.EnumOrder = append(d.root.EnumOrder, enum.Name)
+	}
 	d.enum = enum
 }
 
@@ -268,6 +276,9 @@ func (d *decoder) addMember(member rbxdump.Member) {
 		return
 	}
 	d.class.Members[member.MemberName()] = member
+	if d.retainOrder {
+		d.class.MemberOrder = append(d.class.MemberOrder, member.MemberName())
+	}
 }
 
 // Add an  enum item to the parent enum. Assumes the parent enum exists.
@@ -276,6 +287,9 @@ func (d *decoder) addEnumItem(item *rbxdump.EnumItem) {
 		return
 	}
 	d.enum.Items[item.Name] = item
+	if d.retainOrder {
+		d.enum.ItemOrder = append(d.enum.ItemOrder, item.Name)
+	}
 }
 
 func (d *decoder) decode() error {
@@ -390,9 +404,9 @@ func (d *decoder) decodeFunction(yields bool) {
 	d.skipWhitespace()
 	d.decodeTags(&member.Tags)
 	if yields {
-		member.Tags.SetTag("Yields")
+		member.Tags.SetTag(rbxdump.TagYields)
 	} else {
-		member.Tags.UnsetTag("Yields")
+		member.Tags.UnsetTag(rbxdump.TagYields)
 	}
 	d.addMember(&member)
 }
@@ -507,8 +521,21 @@ func (d *decoder) decodeTag() string {
 	return d.decodeNested('[', ']')
 }
 
+// Decoder decodes an API dump in the legacy format.
+type Decoder struct {
+	// RetainOrder indicates whether the order in which classes, members,
+	// enums, and enum items appear is recorded in the ClassOrder,
+	// MemberOrder, EnumOrder, and ItemOrder fields of the decoded root.
+	RetainOrder bool
+}
+
 // Decode parses an API dump from r.
 func Decode(r io.Reader) (root *rbxdump.Root, err error) {
+	return Decoder{}.Decode(r)
+}
+
+// Decode parses an API dump from r.
+func (dec Decoder) Decode(r io.Reader) (root *rbxdump.Root, err error) {
 	br, ok := r.(io.ByteReader)
 	if !ok {
 		br = bufio.NewReader(r)
@@ -518,9 +545,10 @@ func Decode(r io.Reader) (root *rbxdump.Root, err error) {
 			Classes: make(map[string]*rbxdump.Class),
 			Enums:   make(map[string]*rbxdump.Enum),
 		},
-		r:    br,
-		next: make([]byte, 0, 9),
-		line: 1,
+		r:           br,
+		next:        make([]byte, 0, 9),
+		line:        1,
+		retainOrder: dec.RetainOrder,
 	}
 	err = d.decode()
 	root = d.root
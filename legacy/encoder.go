@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"errors"
 	"io"
+	"sort"
 	"strconv"
 
 	"github.com/robloxapi/rbxdump"
@@ -17,6 +18,8 @@ type encoder struct {
 	line   string
 	indent string
 	prefix string
+
+	retainOrder bool
 }
 
 func (e *encoder) setError(msg string) {
@@ -98,15 +101,40 @@ func (e *encoder) checkChars(check charCheck, noempty bool, s, msg string) {
 	}
 }
 
+// orderedNames returns the keys of m. Keys listed in index are ordered first,
+// by position, followed by the remaining keys ordered by name.
+func orderedNames[V any](m map[string]V, index map[string]int) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ii, iok := index[names[i]]
+		ij, jok := index[names[j]]
+		switch {
+		case iok && jok:
+			return ii < ij
+		case iok != jok:
+			return iok
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
 func (e *encoder) encode() (n int64, err error) {
-	for _, class := range e.root.Classes {
-		e.encodeClass(class)
+	var classes, enums map[string]int
+	if e.retainOrder {
+		classes, enums = e.root.ClassOrderIndex(), e.root.EnumOrderIndex()
+	}
+	for _, name := range orderedNames(e.root.Classes, classes) {
+		e.encodeClass(e.root.Classes[name])
 		if e.err != nil {
 			goto finish
 		}
 	}
-	for _, enum := range e.root.Enums {
-		e.encodeEnum(enum)
+	for _, name := range orderedNames(e.root.Enums, enums) {
+		e.encodeEnum(e.root.Enums[name])
 		if e.err != nil {
 			goto finish
 		}
@@ -130,8 +158,12 @@ func (e *encoder) encodeClass(class *rbxdump.Class) {
 	e.encodeTags(class.Tags)
 	e.writeString(e.line)
 
-	for _, member := range class.Members {
-		e.encodeMember(class, member)
+	var members map[string]int
+	if e.retainOrder {
+		members = class.MemberOrderIndex()
+	}
+	for _, name := range orderedNames(class.Members, members) {
+		e.encodeMember(class, class.Members[name])
 		if e.err != nil {
 			return
 		}
@@ -154,7 +186,7 @@ func (e *encoder) encodeMember(class *rbxdump.Class, member rbxdump.Member) {
 		e.writeString(member.Name)
 		e.encodeTags(member.Tags)
 	case *rbxdump.Function:
-		if member.Tags.GetTag("Yields") {
+		if member.Yields() {
 			e.writeString("YieldFunction ")
 		} else {
 			e.writeString("Function ")
@@ -174,7 +206,7 @@ func (e *encoder) encodeMember(class *rbxdump.Class, member rbxdump.Member) {
 		e.writeString(":")
 		e.writeString(member.Name)
 		e.encodeParameters(member.Parameters, true)
-		e.encodeTags(member.Tags, "Yields")
+		e.encodeTags(member.Tags, rbxdump.TagYields)
 	case *rbxdump.Event:
 		e.writeString("Event ")
 		e.writeString(class.Name)
@@ -245,7 +277,18 @@ func (e *encoder) encodeEnum(enum *rbxdump.Enum) {
 	e.encodeTags(enum.Tags)
 	e.writeString(e.line)
 
-	for _, item := range enum.Items {
+	items := enum.GetEnumItems()
+	if listed := enum.ItemOrderIndex(); e.retainOrder && listed != nil {
+		sort.SliceStable(items, func(i, j int) bool {
+			ii, iok := listed[items[i].Name]
+			ij, jok := listed[items[j].Name]
+			if iok && jok {
+				return ii < ij
+			}
+			return iok && !jok
+		})
+	}
+	for _, item := range items {
 		e.encodeEnumItem(enum, item)
 		if e.err != nil {
 			return
@@ -305,15 +348,34 @@ func (e *encoder) encodeTag(tag string) {
 	e.writeString("]")
 }
 
+// Encoder encodes an API dump in the legacy format.
+type Encoder struct {
+	// RetainOrder indicates whether classes, members, enums, and enum items
+	// are written in the order recorded by the ClassOrder, MemberOrder,
+	// EnumOrder, and ItemOrder fields of the root, so that a root decoded with
+	// Decoder.RetainOrder is encoded in its original order. Unlisted elements
+	// are written after listed elements.
+	//
+	// Otherwise, the order fields are ignored. Classes, members, and enums are
+	// ordered by name, and enum items are ordered as by Enum.GetEnumItems.
+	RetainOrder bool
+}
+
 // Encode encodes root, writing the results to w in the API dump format.
-func Encode(w io.Writer, root *rbxdump.Root) (err error) {
+func (enc Encoder) Encode(w io.Writer, root *rbxdump.Root) (err error) {
 	e := &encoder{
-		w:      bufio.NewWriter(w),
-		root:   root,
-		prefix: "",
-		indent: "\t",
-		line:   "\n",
+		w:           bufio.NewWriter(w),
+		root:        root,
+		prefix:      "",
+		indent:      "\t",
+		line:        "\n",
+		retainOrder: enc.RetainOrder,
 	}
 	_, err = e.encode()
 	return err
 }
+
+// Encode encodes root, writing the results to w in the API dump format.
+func Encode(w io.Writer, root *rbxdump.Root) (err error) {
+	return Encoder{}.Encode(w, root)
+}
@@ -0,0 +1,338 @@
+package rbxdump
+
+import (
+	"sort"
+	"strconv"
+)
+
+// MergePolicy determines how an element of a Merge is resolved when it has
+// differing fields across inputs.
+type MergePolicy int
+
+const (
+	MergeFirst  MergePolicy = iota // Use the fields of the first input that has the element.
+	MergeLast                      // Use the fields of the last input that has the element.
+	MergeStrict                    // Fail if any element has a conflict.
+)
+
+// Conflict describes an element that has differing fields across the inputs
+// of a Merge.
+type Conflict struct {
+	// Path locates the element.
+	Path Path
+	// Inputs contains the index of each input that has the element, in
+	// ascending order. If the element is a member that has differing types,
+	// then Inputs contains each input that has a member of the same name.
+	Inputs []int
+	// Fields contains the names of the fields that differ, ordered by name.
+	// Includes "MemberType" if a member has differing types.
+	Fields []string
+	// MemberTypes contains the type of the member in each input of Inputs, in
+	// the same order. Nil unless Fields includes "MemberType".
+	MemberTypes []string
+}
+
+// sortConflicts sorts Conflict values by path.
+type sortConflicts []Conflict
+
+func (a sortConflicts) Len() int           { return len(a) }
+func (a sortConflicts) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a sortConflicts) Less(i, j int) bool { return a[i].Path.Less(a[j].Path) }
+
+// MergeError indicates that a Merge with the MergeStrict policy encountered
+// conflicts.
+type MergeError interface {
+	error
+	// MergeError returns the conflicts that were encountered.
+	MergeError() []Conflict
+}
+
+// errMerge implements the MergeError interface.
+type errMerge []Conflict
+
+func (err errMerge) Error() string {
+	if len(err) == 1 {
+		return "merge conflict at " + err[0].Path.String()
+	}
+	return strconv.Itoa(len(err)) + " merge conflicts, first at " + err[0].Path.String()
+}
+
+func (err errMerge) MergeError() []Conflict {
+	return err
+}
+
+// Merge combines several Root values into one.
+type Merge struct {
+	// Roots are the inputs to be merged. An input is identified by its index.
+	Roots []*Root
+	// Policy determines how conflicts are resolved.
+	Policy MergePolicy
+}
+
+// MergeResult is the result of a Merge.
+type MergeResult struct {
+	// Root is the union of the inputs. Each element is a copy.
+	Root *Root
+	// Sources maps the path of each element of Root to the index of each input
+	// that has the element, in ascending order.
+	Sources map[Path][]int
+	// Conflicts lists each element that has differing fields across inputs,
+	// ordered by path.
+	Conflicts []Conflict
+}
+
+// differingFields returns the names of fields that differ between a and b.
+func differingFields(a, b Fielder) (list []string) {
+	fa := a.Fields(nil)
+	fb := b.Fields(nil)
+	for name, v := range fa {
+		if u, ok := fb[name]; !ok || !equalValue(v, u) {
+			list = append(list, name)
+		}
+	}
+	for name := range fb {
+		if _, ok := fa[name]; !ok {
+			list = append(list, name)
+		}
+	}
+	return list
+}
+
+// merger holds the state of a merge.
+type merger struct {
+	policy    MergePolicy
+	root      *Root
+	sources   map[Path][]int
+	conflicts map[Path]map[string]bool
+	// Members that have differing types across inputs, by class and member
+	// name.
+	typeConflicts map[[2]string]bool
+	// Names included in the order fields of the merged root.
+	classListed, enumListed  map[string]bool
+	memberListed, itemListed map[string]map[string]bool
+}
+
+// mergeOrder appends to order each name in from that is a key of m, and is
+// not yet in seen. Returns order unchanged if from is nil.
+func mergeOrder[V any](order, from []string, m map[string]V, seen map[string]bool) []string {
+	if from == nil {
+		return order
+	}
+	if order == nil {
+		order = []string{}
+	}
+	for _, name := range from {
+		if _, ok := m[name]; ok && !seen[name] {
+			seen[name] = true
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+func (m *merger) addSource(path Path, input int) {
+	m.sources[path] = append(m.sources[path], input)
+}
+
+func (m *merger) conflict(path Path, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	set := m.conflicts[path]
+	if set == nil {
+		set = map[string]bool{}
+		m.conflicts[path] = set
+	}
+	for _, field := range fields {
+		set[field] = true
+	}
+}
+
+// Merge returns the union of the inputs. An element that appears in more than
+// one input is included once. Nil inputs are skipped.
+//
+// The ClassOrder, EnumOrder, MemberOrder, and ItemOrder fields of the result
+// combine those of the inputs, listing each name in the order it is first
+// seen. Such a field is nil if it is nil in every input.
+//
+// If the Policy is MergeStrict and there are conflicts, then the returned
+// result contains only Conflicts, and a MergeError is returned.
+func (m Merge) Merge() (*MergeResult, error) {
+	s := merger{
+		policy:    m.Policy,
+		root:      &Root{Classes: map[string]*Class{}, Enums: map[string]*Enum{}},
+		sources:   map[Path][]int{},
+		conflicts: map[Path]map[string]bool{},
+
+		typeConflicts: map[[2]string]bool{},
+		classListed:   map[string]bool{},
+		enumListed:    map[string]bool{},
+		memberListed:  map[string]map[string]bool{},
+		itemListed:    map[string]map[string]bool{},
+	}
+	for i, root := range m.Roots {
+		if root == nil {
+			continue
+		}
+		s.root.ClassOrder = mergeOrder(s.root.ClassOrder, root.ClassOrder, root.Classes, s.classListed)
+		s.root.EnumOrder = mergeOrder(s.root.EnumOrder, root.EnumOrder, root.Enums, s.enumListed)
+		for _, name := range sortedKeys(root.Classes) {
+			if class := root.Classes[name]; class != nil {
+				s.mergeClass(i, name, class)
+			}
+		}
+		for _, name := range sortedKeys(root.Enums) {
+			if enum := root.Enums[name]; enum != nil {
+				s.mergeEnum(i, name, enum)
+			}
+		}
+	}
+
+	// Paths of elements that were replaced by a member of a differing type do
+	// not locate an element of the result.
+	for path := range s.sources {
+		if !hasPath(s.root, path) {
+			delete(s.sources, path)
+			delete(s.conflicts, path)
+		}
+	}
+	inputs := map[Path][]int{}
+	types := map[Path][]string{}
+	for key := range s.typeConflicts {
+		member := s.root.Classes[key[0]].Members[key[1]]
+		path := Path{Element: member.MemberType(), Primary: key[0], Secondary: key[1]}
+		s.conflict(path, []string{"MemberType"})
+		for i, root := range m.Roots {
+			if root == nil || root.Classes[key[0]] == nil {
+				continue
+			}
+			if member := root.Classes[key[0]].Members[key[1]]; member != nil {
+				inputs[path] = append(inputs[path], i)
+				types[path] = append(types[path], member.MemberType())
+			}
+		}
+	}
+
+	result := &MergeResult{}
+	for path, set := range s.conflicts {
+		if inputs[path] == nil {
+			inputs[path] = append([]int(nil), s.sources[path]...)
+		}
+		result.Conflicts = append(result.Conflicts, Conflict{
+			Path:        path,
+			Inputs:      inputs[path],
+			Fields:      sortedKeys(set),
+			MemberTypes: types[path],
+		})
+	}
+	sort.Sort(sortConflicts(result.Conflicts))
+	if m.Policy == MergeStrict && len(result.Conflicts) > 0 {
+		return result, errMerge(result.Conflicts)
+	}
+	result.Root = s.root
+	result.Sources = s.sources
+	return result, nil
+}
+
+func (m *merger) mergeClass(input int, name string, class *Class) {
+	path := ClassPath(name)
+	m.addSource(path, input)
+	mclass := m.root.Classes[name]
+	if mclass == nil {
+		mclass = &Class{Name: class.Name, Members: make(map[string]Member, len(class.Members))}
+		mclass.SetFields(class.Fields(nil))
+		m.root.Classes[name] = mclass
+	} else {
+		m.conflict(path, differingFields(mclass, class))
+		if m.policy == MergeLast {
+			mclass.SetFields(class.Fields(nil))
+		}
+	}
+	if m.memberListed[name] == nil {
+		m.memberListed[name] = map[string]bool{}
+	}
+	mclass.MemberOrder = mergeOrder(mclass.MemberOrder, class.MemberOrder, class.Members, m.memberListed[name])
+	for _, mname := range sortedKeys(class.Members) {
+		member := class.Members[mname]
+		if member == nil {
+			continue
+		}
+		path := Path{Element: member.MemberType(), Primary: name, Secondary: mname}
+		m.addSource(path, input)
+		prev := mclass.Members[mname]
+		if prev == nil {
+			mclass.Members[mname] = member.MemberCopy()
+			continue
+		}
+		if prev.MemberType() != member.MemberType() {
+			// Members of differing types are located by differing paths. The
+			// conflict is recorded under the path of the surviving member once
+			// all inputs are merged.
+			m.typeConflicts[[2]string{name, mname}] = true
+			if m.policy == MergeLast {
+				mclass.Members[mname] = member.MemberCopy()
+			}
+			continue
+		}
+		m.conflict(path, differingFields(prev, member))
+		if m.policy == MergeLast {
+			mclass.Members[mname] = member.MemberCopy()
+		}
+	}
+}
+
+func (m *merger) mergeEnum(input int, name string, enum *Enum) {
+	path := EnumPath(name)
+	m.addSource(path, input)
+	menum := m.root.Enums[name]
+	if menum == nil {
+		menum = &Enum{Name: enum.Name, Items: make(map[string]*EnumItem, len(enum.Items))}
+		menum.SetFields(enum.Fields(nil))
+		m.root.Enums[name] = menum
+	} else {
+		m.conflict(path, differingFields(menum, enum))
+		if m.policy == MergeLast {
+			menum.SetFields(enum.Fields(nil))
+		}
+	}
+	if m.itemListed[name] == nil {
+		m.itemListed[name] = map[string]bool{}
+	}
+	menum.ItemOrder = mergeOrder(menum.ItemOrder, enum.ItemOrder, enum.Items, m.itemListed[name])
+	for _, iname := range sortedKeys(enum.Items) {
+		item := enum.Items[iname]
+		if item == nil {
+			continue
+		}
+		path := EnumItemPath(name, iname)
+		m.addSource(path, input)
+		prev := menum.Items[iname]
+		if prev == nil {
+			menum.Items[iname] = item.Copy()
+			continue
+		}
+		m.conflict(path, differingFields(prev, item))
+		if m.policy == MergeLast {
+			menum.Items[iname] = item.Copy()
+		}
+	}
+}
+
+// hasPath returns whether path locates an element of root.
+func hasPath(root *Root, path Path) bool {
+	switch path.Element {
+	case "Class":
+		return root.Classes[path.Primary] != nil
+	case "Enum":
+		return root.Enums[path.Primary] != nil
+	case "EnumItem":
+		enum := root.Enums[path.Primary]
+		return enum != nil && enum.Items[path.Secondary] != nil
+	}
+	class := root.Classes[path.Primary]
+	if class == nil {
+		return false
+	}
+	member := class.Members[path.Secondary]
+	return member != nil && member.MemberType() == path.Element
+}
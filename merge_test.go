@@ -0,0 +1,60 @@
+package rbxdump
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeMemberType(t *testing.T) {
+	input := func(member Member) *Root {
+		return &Root{Classes: map[string]*Class{
+			"Part": {Name: "Part", Members: map[string]Member{"Size": member}},
+		}}
+	}
+	roots := []*Root{
+		input(&Property{Name: "Size"}),
+		input(&Function{Name: "Size"}),
+		input(&Property{Name: "Size", Category: "Data"}),
+	}
+	property := Path{Element: "Property", Primary: "Part", Secondary: "Size"}
+	function := Path{Element: "Function", Primary: "Part", Secondary: "Size"}
+	tests := []struct {
+		policy    MergePolicy
+		survivor  Path
+		loser     Path
+		sources   []int
+		conflicts []Conflict
+	}{
+		{MergeFirst, property, function, []int{0, 2}, []Conflict{{
+			Path:        property,
+			Inputs:      []int{0, 1, 2},
+			Fields:      []string{"Category", "MemberType"},
+			MemberTypes: []string{"Property", "Function", "Property"},
+		}}},
+		{MergeLast, property, function, []int{0, 2}, []Conflict{{
+			Path:        property,
+			Inputs:      []int{0, 1, 2},
+			Fields:      []string{"MemberType"},
+			MemberTypes: []string{"Property", "Function", "Property"},
+		}}},
+	}
+	for _, test := range tests {
+		result, err := Merge{Roots: roots, Policy: test.policy}.Merge()
+		if err != nil {
+			t.Errorf("policy %d: unexpected error: %v", test.policy, err)
+			continue
+		}
+		if !hasPath(result.Root, test.survivor) {
+			t.Errorf("policy %d: result lacks %s", test.policy, test.survivor)
+		}
+		if got := result.Sources[test.survivor]; !reflect.DeepEqual(got, test.sources) {
+			t.Errorf("policy %d: sources of %s: got %v, want %v", test.policy, test.survivor, got, test.sources)
+		}
+		if got, ok := result.Sources[test.loser]; ok {
+			t.Errorf("policy %d: unexpected sources of %s: %v", test.policy, test.loser, got)
+		}
+		if !reflect.DeepEqual(result.Conflicts, test.conflicts) {
+			t.Errorf("policy %d: got conflicts %+v, want %+v", test.policy, result.Conflicts, test.conflicts)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package rbxdump
+
+import (
+	"hash/maphash"
+)
+
+// orderIndex maps each name in order that is a key of m to its position among
+// such names. Only the first occurrence of a duplicate name is included.
+func orderIndex[V any](order []string, m map[string]V) map[string]int {
+	if order == nil {
+		return nil
+	}
+	index := make(map[string]int, len(order))
+	for _, name := range order {
+		if _, ok := m[name]; !ok {
+			continue
+		}
+		if _, ok := index[name]; !ok {
+			index[name] = len(index)
+		}
+	}
+	return index
+}
+
+// orderSeed seeds the hashes used by listsKeys.
+var orderSeed = maphash.MakeSeed()
+
+// listsKeys returns whether order names each key of m exactly once, without
+// allocating. If order has as many names as m has keys, each being a key of
+// m, then order lacks a key only if it has a duplicate. This is detected by
+// comparing the sums of the hashes of the names and the keys.
+func listsKeys[V any](order []string, m map[string]V) bool {
+	if len(order) == 0 || len(order) != len(m) {
+		return false
+	}
+	var sum uint64
+	for _, name := range order {
+		if _, ok := m[name]; !ok {
+			return false
+		}
+		sum += maphash.String(orderSeed, name)
+	}
+	for name := range m {
+		sum -= maphash.String(orderSeed, name)
+	}
+	return sum == 0
+}
+
+// orderedKeys returns order if it names each key of m exactly once, and the
+// keys of m in ascending order otherwise.
+func orderedKeys[V any](order []string, m map[string]V) []string {
+	if listsKeys(order, m) {
+		return order
+	}
+	return sortedKeys(m)
+}
+
+// ClassOrderIndex returns the position of each class listed in ClassOrder,
+// excluding names that do not refer to a class. Returns nil if ClassOrder is
+// nil.
+func (root *Root) ClassOrderIndex() map[string]int {
+	return orderIndex(root.ClassOrder, root.Classes)
+}
+
+// EnumOrderIndex returns the position of each enum listed in EnumOrder,
+// excluding names that do not refer to an enum. Returns nil if EnumOrder is
+// nil.
+func (root *Root) EnumOrderIndex() map[string]int {
+	return orderIndex(root.EnumOrder, root.Enums)
+}
+
+// MemberOrderIndex returns the position of each member listed in MemberOrder,
+// excluding names that do not refer to a member. Returns nil if MemberOrder
+// is nil.
+func (class *Class) MemberOrderIndex() map[string]int {
+	return orderIndex(class.MemberOrder, class.Members)
+}
+
+// ItemOrderIndex returns the position of each item listed in ItemOrder,
+// excluding names that do not refer to an item. Returns nil if ItemOrder is
+// nil.
+func (enum *Enum) ItemOrderIndex() map[string]int {
+	return orderIndex(enum.ItemOrder, enum.Items)
+}
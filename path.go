@@ -0,0 +1,63 @@
+package rbxdump
+
+// Path locates an element within a Root.
+type Path struct {
+	// Element is the kind of element, being one of "Class", "Property",
+	// "Function", "Event", "Callback", "Enum", or "EnumItem".
+	Element string
+	// Primary is the name of the class or enum.
+	Primary string
+	// Secondary is the name of the member or enum item. Empty for classes and
+	// enums.
+	Secondary string
+}
+
+// ClassPath returns a Path locating a class.
+func ClassPath(class string) Path {
+	return Path{Element: "Class", Primary: class}
+}
+
+// MemberPath returns a Path locating a member of a class.
+func MemberPath(class string, member Member) Path {
+	return Path{Element: member.MemberType(), Primary: class, Secondary: member.MemberName()}
+}
+
+// EnumPath returns a Path locating an enum.
+func EnumPath(enum string) Path {
+	return Path{Element: "Enum", Primary: enum}
+}
+
+// EnumItemPath returns a Path locating an item of an enum.
+func EnumItemPath(enum, item string) Path {
+	return Path{Element: "EnumItem", Primary: enum, Secondary: item}
+}
+
+// IsEnum returns whether the path locates an enum or enum item.
+func (p Path) IsEnum() bool {
+	return p.Element == "Enum" || p.Element == "EnumItem"
+}
+
+// String returns a string representation of the path.
+func (p Path) String() string {
+	if p.Secondary == "" {
+		return p.Element + " " + p.Primary
+	}
+	return p.Element + " " + p.Primary + "." + p.Secondary
+}
+
+// Less returns whether p is ordered before q. Classes are ordered before
+// enums. Then paths are ordered by primary name, where an outer element is
+// ordered before its inner elements. Finally, paths are ordered by secondary
+// name, then element.
+func (p Path) Less(q Path) bool {
+	if pe, qe := p.IsEnum(), q.IsEnum(); pe != qe {
+		return qe
+	}
+	if p.Primary != q.Primary {
+		return p.Primary < q.Primary
+	}
+	if p.Secondary != q.Secondary {
+		return p.Secondary < q.Secondary
+	}
+	return p.Element < q.Element
+}
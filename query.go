@@ -0,0 +1,358 @@
+package rbxdump
+
+import (
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// QueryError indicates that a query could not be parsed.
+type QueryError interface {
+	error
+	// QueryError returns an error message and the byte offset within the
+	// query at which the error occurred.
+	QueryError() (msg string, offset int)
+}
+
+// errQuery implements the QueryError interface.
+type errQuery struct {
+	msg    string
+	offset int
+}
+
+func (err errQuery) Error() string {
+	return "query error at offset " + strconv.Itoa(err.offset) + ": " + err.msg
+}
+
+func (err errQuery) QueryError() (msg string, offset int) {
+	return err.msg, err.offset
+}
+
+// queryFilter compares a field of an element with a value.
+type queryFilter struct {
+	field string
+	value string
+	not   bool
+}
+
+// Query selects elements of a Root. A query is parsed from a string of
+// segments separated by dots:
+//
+//	Kind[filters].Name[filters].MemberKind[filters].MemberName[filters]
+//
+// Kind is "Class", "Enum", or "*" to select both, and is required. Name is a
+// glob pattern, as in path.Match, matching the name of the class or enum.
+// MemberKind is one of "Property", "Function", "Event", "Callback", "Member"
+// to select any member, "EnumItem", or "*" to select any member or item.
+// MemberName is a glob pattern matching the name of the member or item. A
+// pattern of "*" matches any name.
+//
+// Segments are identified by position, except that trailing segments may be
+// omitted as follows:
+//
+//   - Kind selects classes and enums.
+//   - Kind.Name selects classes and enums matching Name.
+//   - Kind.MemberKind, where the second segment is a member kind other than
+//     "*", is short for Kind.*.MemberKind.
+//   - Kind.Name.MemberKind selects members and items of matching classes and
+//     enums.
+//   - Kind.Name.MemberName, where the third segment is not a member kind, is
+//     short for Kind.Name.*.MemberName.
+//
+// As a result, a class or enum whose name is a member kind must be selected
+// with a filter, as in Class[Name=Function], and a member whose name is a
+// member kind must be selected with all four segments, as in
+// Class.Part.*.Function.
+//
+// Each segment may be followed by any number of filters of the form
+// [Field=Value] or [Field!=Value], which apply to the element selected by the
+// segment. Field is the name of a field returned by the element's Fields
+// method, "Name", or "MemberType". Value is compared with the field according
+// to the field's type:
+//
+//   - string, bool, int: the value is equal to the formatted field.
+//   - Type: the value is equal to the result of Type.String.
+//   - []Type: the value is equal to any type.
+//   - []Parameter: the value is equal to the type of any parameter.
+//   - Tags, []string: the value is contained in the list.
+//   - PreferredDescriptor: the value is equal to the descriptor's name.
+//
+// A filter with = does not match if the element does not have the field,
+// while a filter with != does.
+//
+// Examples:
+//
+//	Class[Tags=Service].Function[Security!=None]
+//	Enum.Material.*
+//	*.Property[ValueType=Class:Instance]
+//	Class.*.Function
+type Query struct {
+	primaryKind      string
+	primaryName      string
+	primaryFilters   []queryFilter
+	secondaryKind    string
+	secondaryName    string
+	secondaryFilters []queryFilter
+}
+
+// isSecondaryKind returns whether s names a kind of secondary element.
+func isSecondaryKind(s string) bool {
+	switch s {
+	case "Property", "Function", "Event", "Callback", "Member", "EnumItem", "*":
+		return true
+	}
+	return false
+}
+
+// querySegment is a segment of a query string.
+type querySegment struct {
+	name    string
+	offset  int
+	filters []queryFilter
+}
+
+// parseSegments splits s into segments.
+func parseSegments(s string) ([]querySegment, error) {
+	var segments []querySegment
+	i := 0
+	for {
+		seg := querySegment{offset: i}
+		j := strings.IndexAny(s[i:], ".[]")
+		if j < 0 {
+			j = len(s) - i
+		}
+		seg.name = s[i : i+j]
+		if seg.name == "" {
+			return nil, errQuery{msg: "expected name", offset: i}
+		}
+		i += j
+		for i < len(s) && s[i] == '[' {
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, errQuery{msg: "unterminated filter", offset: i}
+			}
+			f, ok := parseFilter(s[i+1 : i+end])
+			if !ok {
+				return nil, errQuery{msg: "expected Field=Value or Field!=Value", offset: i + 1}
+			}
+			seg.filters = append(seg.filters, f)
+			i += end + 1
+		}
+		segments = append(segments, seg)
+		if i == len(s) {
+			return segments, nil
+		}
+		if s[i] != '.' {
+			return nil, errQuery{msg: "unexpected '" + string(s[i]) + "'", offset: i}
+		}
+		i++
+	}
+}
+
+// parseFilter parses the content of a filter.
+func parseFilter(s string) (f queryFilter, ok bool) {
+	i := strings.IndexByte(s, '=')
+	if i < 0 {
+		return f, false
+	}
+	f.field, f.value = s[:i], s[i+1:]
+	if strings.HasSuffix(f.field, "!") {
+		f.field = strings.TrimSuffix(f.field, "!")
+		f.not = true
+	}
+	return f, f.field != ""
+}
+
+// ParseQuery parses s into a Query. Returns a QueryError if s is malformed.
+func ParseQuery(s string) (*Query, error) {
+	segments, err := parseSegments(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) > 4 {
+		return nil, errQuery{msg: "unexpected segment", offset: segments[4].offset}
+	}
+	kind := segments[0]
+	switch kind.name {
+	case "Class", "Enum", "*":
+	default:
+		return nil, errQuery{msg: "expected Class, Enum, or *", offset: kind.offset}
+	}
+
+	// Expand shorthand forms to the segments they stand for.
+	wildcard := func(offset int) querySegment {
+		return querySegment{name: "*", offset: offset}
+	}
+	switch {
+	case len(segments) == 2 && segments[1].name != "*" && isSecondaryKind(segments[1].name):
+		segments = []querySegment{kind, wildcard(segments[1].offset), segments[1]}
+	case len(segments) == 3 && !isSecondaryKind(segments[2].name):
+		segments = []querySegment{kind, segments[1], wildcard(segments[2].offset), segments[2]}
+	}
+
+	q := &Query{primaryKind: kind.name, primaryFilters: kind.filters}
+	if len(segments) > 1 {
+		name := segments[1]
+		if _, err := path.Match(name.name, ""); err != nil {
+			return nil, errQuery{msg: "malformed pattern", offset: name.offset}
+		}
+		q.primaryName = name.name
+		q.primaryFilters = append(q.primaryFilters, name.filters...)
+	}
+	if len(segments) > 2 {
+		mkind := segments[2]
+		if !isSecondaryKind(mkind.name) {
+			return nil, errQuery{msg: "expected member kind", offset: mkind.offset}
+		}
+		q.secondaryKind = mkind.name
+		q.secondaryFilters = mkind.filters
+	}
+	if len(segments) > 3 {
+		name := segments[3]
+		if _, err := path.Match(name.name, ""); err != nil {
+			return nil, errQuery{msg: "malformed pattern", offset: name.offset}
+		}
+		q.secondaryName = name.name
+		q.secondaryFilters = append(q.secondaryFilters, name.filters...)
+	}
+	switch {
+	case q.primaryKind == "Class" && q.secondaryKind == "EnumItem":
+		return nil, errQuery{msg: "EnumItem does not apply to Class", offset: 0}
+	case q.primaryKind == "Enum" && q.secondaryKind != "" && q.secondaryKind != "EnumItem" && q.secondaryKind != "*":
+		return nil, errQuery{msg: q.secondaryKind + " does not apply to Enum", offset: 0}
+	}
+	return q, nil
+}
+
+// QueryResult is an element selected by a Query.
+type QueryResult struct {
+	// Path locates the element.
+	Path Path
+	// Element is the selected element, being a *Class, Member, *Enum, or
+	// *EnumItem.
+	Element Fielder
+}
+
+// Select returns the elements of root that match the query. Classes and their
+// members are ordered before enums and their items. Classes, members, enums,
+// and enum items are ordered as by IterClasses, IterMembers, IterEnums, and
+// IterEnumItems.
+func (q *Query) Select(root *Root) []QueryResult {
+	var results []QueryResult
+	if q.primaryKind == "Class" || q.primaryKind == "*" {
+		for class := range root.IterClasses() {
+			if !matchName(q.primaryName, class.Name) || !matchFilters(q.primaryFilters, class.Name, class) {
+				continue
+			}
+			if q.secondaryKind == "" {
+				results = append(results, QueryResult{Path: ClassPath(class.Name), Element: class})
+				continue
+			}
+			for member := range class.IterMembers() {
+				if !q.matchMemberKind(member) ||
+					!matchName(q.secondaryName, member.MemberName()) ||
+					!matchFilters(q.secondaryFilters, member.MemberName(), member) {
+					continue
+				}
+				results = append(results, QueryResult{Path: MemberPath(class.Name, member), Element: member})
+			}
+		}
+	}
+	if q.primaryKind == "Enum" || q.primaryKind == "*" {
+		for enum := range root.IterEnums() {
+			if !matchName(q.primaryName, enum.Name) || !matchFilters(q.primaryFilters, enum.Name, enum) {
+				continue
+			}
+			if q.secondaryKind == "" {
+				results = append(results, QueryResult{Path: EnumPath(enum.Name), Element: enum})
+				continue
+			}
+			if q.secondaryKind != "EnumItem" && q.secondaryKind != "*" {
+				continue
+			}
+			for item := range enum.IterEnumItems() {
+				if !matchName(q.secondaryName, item.Name) || !matchFilters(q.secondaryFilters, item.Name, item) {
+					continue
+				}
+				results = append(results, QueryResult{Path: EnumItemPath(enum.Name, item.Name), Element: item})
+			}
+		}
+	}
+	return results
+}
+
+// matchMemberKind returns whether member is of the query's secondary kind.
+func (q *Query) matchMemberKind(member Member) bool {
+	switch q.secondaryKind {
+	case "Member", "*":
+		return true
+	}
+	return member.MemberType() == q.secondaryKind
+}
+
+// matchName returns whether name matches pattern. An empty pattern matches
+// any name.
+func matchName(pattern, name string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
+
+// matchFilters returns whether the element of the given name matches every
+// filter.
+func matchFilters(filters []queryFilter, name string, e Fielder) bool {
+	for _, f := range filters {
+		var v any
+		var ok bool
+		switch f.field {
+		case "Name":
+			v, ok = name, true
+		case "MemberType":
+			if member, isMember := e.(Member); isMember {
+				v, ok = member.MemberType(), true
+			}
+		default:
+			v, ok = e.Fields(Fields{f.field: nil})[f.field]
+		}
+		if (ok && matchValue(v, f.value)) == f.not {
+			return false
+		}
+	}
+	return true
+}
+
+// matchValue returns whether the field value v matches s.
+func matchValue(v any, s string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == s
+	case bool:
+		return strconv.FormatBool(v) == s
+	case int:
+		return strconv.Itoa(v) == s
+	case Type:
+		return v.String() == s
+	case []Type:
+		for _, typ := range v {
+			if typ.String() == s {
+				return true
+			}
+		}
+	case []Parameter:
+		for _, param := range v {
+			if param.Type.String() == s {
+				return true
+			}
+		}
+	case Tags:
+		return v.GetTag(s)
+	case []string:
+		return slices.Contains(v, s)
+	case PreferredDescriptor:
+		return v.Name == s
+	}
+	return false
+}
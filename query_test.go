@@ -0,0 +1,127 @@
+package rbxdump
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Query
+	}{
+		{"Class", Query{primaryKind: "Class"}},
+		{"Class.Part", Query{primaryKind: "Class", primaryName: "Part"}},
+		{"Class.*", Query{primaryKind: "Class", primaryName: "*"}},
+		{
+			"Class[Tags=Service].Function[Security!=None]",
+			Query{
+				primaryKind:      "Class",
+				primaryName:      "*",
+				primaryFilters:   []queryFilter{{field: "Tags", value: "Service"}},
+				secondaryKind:    "Function",
+				secondaryFilters: []queryFilter{{field: "Security", value: "None", not: true}},
+			},
+		},
+		{"Enum.Material.*", Query{primaryKind: "Enum", primaryName: "Material", secondaryKind: "*"}},
+		{
+			"*.Property[ValueType=Class:Instance]",
+			Query{
+				primaryKind:      "*",
+				primaryName:      "*",
+				secondaryKind:    "Property",
+				secondaryFilters: []queryFilter{{field: "ValueType", value: "Class:Instance"}},
+			},
+		},
+		{"Class.*.Function", Query{primaryKind: "Class", primaryName: "*", secondaryKind: "Function"}},
+		{"Class.Part.Function", Query{primaryKind: "Class", primaryName: "Part", secondaryKind: "Function"}},
+		{"Class.Part.Size", Query{primaryKind: "Class", primaryName: "Part", secondaryKind: "*", secondaryName: "Size"}},
+		{"Class.Part.*.Function", Query{primaryKind: "Class", primaryName: "Part", secondaryKind: "*", secondaryName: "Function"}},
+		{"Class.Function.Size", Query{primaryKind: "Class", primaryName: "Function", secondaryKind: "*", secondaryName: "Size"}},
+	}
+	for _, test := range tests {
+		q, err := ParseQuery(test.query)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(*q, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.query, *q, test.want)
+		}
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	tests := []struct {
+		query  string
+		offset int
+	}{
+		{"", 0},
+		{"Part", 0},
+		{"Class.Part.Size.Function", 11},
+		{"Class.Part.*.Size.Name", 18},
+		{"Class.Part[Tags]", 11},
+		{"Class.Part[Tags=Service", 10},
+		{"Class.[", 6},
+		{"Class.Part.EnumItem", 0},
+		{"Enum.Material.Property", 0},
+	}
+	for _, test := range tests {
+		_, err := ParseQuery(test.query)
+		qerr, ok := err.(QueryError)
+		if !ok {
+			t.Errorf("%s: expected QueryError, got %v", test.query, err)
+			continue
+		}
+		if _, offset := qerr.QueryError(); offset != test.offset {
+			t.Errorf("%s: got offset %d, want %d", test.query, offset, test.offset)
+		}
+	}
+}
+
+func TestQuerySelect(t *testing.T) {
+	root := &Root{
+		Classes: map[string]*Class{
+			"Workspace": {Name: "Workspace", Tags: Tags{"Service"}, Members: map[string]Member{
+				"Raycast":  &Function{Name: "Raycast", Security: "None"},
+				"Function": &Property{Name: "Function", ValueType: Type{Category: CategoryClass, Name: "Instance"}},
+			}},
+			"Part": {Name: "Part", Members: map[string]Member{
+				"Clone":  &Function{Name: "Clone", Security: "PluginSecurity"},
+				"Parent": &Property{Name: "Parent", ValueType: Type{Category: CategoryClass, Name: "Instance"}},
+			}},
+		},
+		Enums: map[string]*Enum{
+			"Material": {Name: "Material", Items: map[string]*EnumItem{
+				"Plastic": {Name: "Plastic", Value: 256},
+				"Wood":    {Name: "Wood", Value: 512, Index: 1},
+			}},
+		},
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"Class[Tags=Service].Function[Security!=None]", nil},
+		{"Class[Tags=Service].Function[Security=None]", []string{"Function Workspace.Raycast"}},
+		{"Class.*.Function", []string{"Function Part.Clone", "Function Workspace.Raycast"}},
+		{"Class.Workspace.*.Function", []string{"Property Workspace.Function"}},
+		{"Enum.Material.*", []string{"EnumItem Material.Plastic", "EnumItem Material.Wood"}},
+		{"*.Property[ValueType=Class:Instance]", []string{"Property Part.Parent", "Property Workspace.Function"}},
+		{"*.P*", []string{"Class Part"}},
+	}
+	for _, test := range tests {
+		q, err := ParseQuery(test.query)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.query, err)
+			continue
+		}
+		var got []string
+		for _, result := range q.Select(root) {
+			got = append(got, result.Path.String())
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %q, want %q", test.query, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package rbxdump
+
+// SecurityLevel is the security level required to access a member, or the
+// level of identity held by a script context. Levels are ordered, such that a
+// context may access members requiring the context's level or lower.
+type SecurityLevel int
+
+const (
+	SecurityNone          SecurityLevel = iota // None: accessible by any context.
+	SecurityPlugin                             // PluginSecurity: accessible by plugins.
+	SecurityLocalUser                          // LocalUserSecurity: accessible by the command bar.
+	SecurityRobloxScript                       // RobloxScriptSecurity: accessible by CoreScripts.
+	SecurityRoblox                             // RobloxSecurity: accessible by Roblox only.
+	SecurityNotAccessible                      // NotAccessibleSecurity: accessible by no context.
+)
+
+// securityNames maps each SecurityLevel to its name within API dumps.
+var securityNames = [...]string{
+	SecurityNone:          "None",
+	SecurityPlugin:        "PluginSecurity",
+	SecurityLocalUser:     "LocalUserSecurity",
+	SecurityRobloxScript:  "RobloxScriptSecurity",
+	SecurityRoblox:        "RobloxSecurity",
+	SecurityNotAccessible: "NotAccessibleSecurity",
+}
+
+// ParseSecurity returns the SecurityLevel corresponding to the name of a
+// security level as it appears in API dumps. An empty string is treated as
+// None. Returns false if the name is not known.
+func ParseSecurity(s string) (level SecurityLevel, ok bool) {
+	if s == "" {
+		return SecurityNone, true
+	}
+	for level, name := range securityNames {
+		if name == s {
+			return SecurityLevel(level), true
+		}
+	}
+	return SecurityNotAccessible, false
+}
+
+// String returns the name of the security level as it appears in API dumps.
+func (l SecurityLevel) String() string {
+	if l < 0 || int(l) >= len(securityNames) {
+		return "<invalid>"
+	}
+	return securityNames[l]
+}
+
+// Permits returns whether a context with identity l may access a member that
+// requires the security level of the given name. A level that is not known is
+// never permitted.
+func (l SecurityLevel) Permits(security string) bool {
+	req, ok := ParseSecurity(security)
+	return ok && req != SecurityNotAccessible && req <= l
+}
+
+// CanRead returns whether a context with the given identity may read the
+// member. For a property, this is determined by ReadSecurity. For other
+// members, this is determined by Security, and indicates whether the member
+// can be indexed. Members tagged as NotScriptable are never readable.
+func CanRead(member Member, identity SecurityLevel) bool {
+	if member.GetTag(TagNotScriptable) {
+		return false
+	}
+	switch member := member.(type) {
+	case *Property:
+		return identity.Permits(member.ReadSecurity)
+	case *Function:
+		return identity.Permits(member.Security)
+	case *Event:
+		return identity.Permits(member.Security)
+	case *Callback:
+		return identity.Permits(member.Security)
+	}
+	return false
+}
+
+// CanWrite returns whether a context with the given identity may assign to
+// the member. A property is writable if permitted by WriteSecurity and not
+// tagged as ReadOnly. A callback is writable if permitted by Security. Other
+// members are never writable. Members tagged as NotScriptable are never
+// writable.
+func CanWrite(member Member, identity SecurityLevel) bool {
+	if member.GetTag(TagNotScriptable) {
+		return false
+	}
+	switch member := member.(type) {
+	case *Property:
+		return !member.GetTag(TagReadOnly) && identity.Permits(member.WriteSecurity)
+	case *Callback:
+		return identity.Permits(member.Security)
+	}
+	return false
+}
+
+// CanCall returns whether a context with the given identity may call the
+// member. Only functions are callable. Members tagged as NotScriptable are
+// never callable.
+func CanCall(member Member, identity SecurityLevel) bool {
+	if member.GetTag(TagNotScriptable) {
+		return false
+	}
+	if member, ok := member.(*Function); ok {
+		return identity.Permits(member.Security)
+	}
+	return false
+}
+
+// CanAccess returns whether a context with the given identity may read,
+// write, or call the member.
+func CanAccess(member Member, identity SecurityLevel) bool {
+	return CanRead(member, identity) || CanWrite(member, identity) || CanCall(member, identity)
+}
+
+// AccessibleTo returns a Predicate that is satisfied by members that are
+// accessible to a context with the given identity, as determined by
+// CanAccess. Elements that are not members always satisfy the predicate.
+func AccessibleTo(identity SecurityLevel) Predicate {
+	return func(path Path, element Fielder) bool {
+		if member, ok := element.(Member); ok {
+			return CanAccess(member, identity)
+		}
+		return true
+	}
+}
+
+// Accessible returns a deep copy of the root containing only the members that
+// are accessible to a context with the given identity. All classes and enums
+// are retained.
+func (root *Root) Accessible(identity SecurityLevel) *Root {
+	return root.Filter(AccessibleTo(identity))
+}
@@ -0,0 +1,162 @@
+package rbxdump
+
+import (
+	"slices"
+)
+
+// shallowClass returns a copy of class without members.
+func shallowClass(class *Class) *Class {
+	c := *class
+	c.Members = map[string]Member{}
+	c.Tags = class.GetTags()
+	c.MemberOrder = slices.Clone(class.MemberOrder)
+	return &c
+}
+
+// shallowEnum returns a copy of enum without items.
+func shallowEnum(enum *Enum) *Enum {
+	e := *enum
+	e.Items = map[string]*EnumItem{}
+	e.Tags = enum.GetTags()
+	e.ItemOrder = slices.Clone(enum.ItemOrder)
+	return &e
+}
+
+// Intersect returns a new Root containing copies of the elements of the root
+// that are present in every one of others with equal fields. Elements are
+// compared as by the Equal methods, except that classes and enums are compared
+// without their members and items. A class or enum whose fields are not equal
+// across every root is excluded along with all of its members or items.
+//
+// If others is empty, then a copy of the root is returned. A nil root in
+// others is treated as empty.
+func (root *Root) Intersect(others ...*Root) *Root {
+	iroot := &Root{
+		Classes:    map[string]*Class{},
+		Enums:      map[string]*Enum{},
+		ClassOrder: slices.Clone(root.ClassOrder),
+		EnumOrder:  slices.Clone(root.EnumOrder),
+	}
+	for _, other := range others {
+		if other == nil {
+			return iroot
+		}
+	}
+classes:
+	for name, class := range root.Classes {
+		if class == nil {
+			continue
+		}
+		for _, other := range others {
+			c := other.Classes[name]
+			if c == nil || c.Name != class.Name || !equalFields(class, c) {
+				continue classes
+			}
+		}
+		iclass := shallowClass(class)
+	members:
+		for mname, member := range class.Members {
+			if member == nil {
+				continue
+			}
+			for _, other := range others {
+				if !member.MemberEqual(other.Classes[name].Members[mname]) {
+					continue members
+				}
+			}
+			iclass.Members[mname] = member.MemberCopy()
+		}
+		iroot.Classes[name] = iclass
+	}
+enums:
+	for name, enum := range root.Enums {
+		if enum == nil {
+			continue
+		}
+		for _, other := range others {
+			e := other.Enums[name]
+			if e == nil || e.Name != enum.Name || !equalFields(enum, e) {
+				continue enums
+			}
+		}
+		ienum := shallowEnum(enum)
+	items:
+		for iname, item := range enum.Items {
+			if item == nil {
+				continue
+			}
+			for _, other := range others {
+				if i := other.Enums[name].Items[iname]; i == nil || !item.Equal(i) {
+					continue items
+				}
+			}
+			ienum.Items[iname] = item.Copy()
+		}
+		iroot.Enums[name] = ienum
+	}
+	return iroot
+}
+
+// Subtract returns a new Root containing copies of the elements of the root
+// that are not present in other. Elements are matched by name, and members
+// are additionally matched by member type; fields are not compared.
+//
+// A class or enum that is not present in other is included along with all of
+// its members or items. A class or enum that is present in other is included
+// only if it has at least one member or item that is not present in other, in
+// which case it contains only such members or items.
+func (root *Root) Subtract(other *Root) *Root {
+	if other == nil {
+		return root.Copy()
+	}
+	sroot := &Root{
+		Classes:    map[string]*Class{},
+		Enums:      map[string]*Enum{},
+		ClassOrder: slices.Clone(root.ClassOrder),
+		EnumOrder:  slices.Clone(root.EnumOrder),
+	}
+	for name, class := range root.Classes {
+		if class == nil {
+			continue
+		}
+		c := other.Classes[name]
+		if c == nil {
+			sroot.Classes[name] = class.Copy()
+			continue
+		}
+		sclass := shallowClass(class)
+		for mname, member := range class.Members {
+			if member == nil {
+				continue
+			}
+			if m := c.Members[mname]; m != nil && m.MemberType() == member.MemberType() {
+				continue
+			}
+			sclass.Members[mname] = member.MemberCopy()
+		}
+		if len(sclass.Members) > 0 {
+			sroot.Classes[name] = sclass
+		}
+	}
+	for name, enum := range root.Enums {
+		if enum == nil {
+			continue
+		}
+		e := other.Enums[name]
+		if e == nil {
+			sroot.Enums[name] = enum.Copy()
+			continue
+		}
+		senum := shallowEnum(enum)
+		for iname, item := range enum.Items {
+			if item == nil || e.Items[iname] != nil {
+				continue
+			}
+			senum.Items[iname] = item.Copy()
+		}
+		if len(senum.Items) > 0 {
+			sroot.Enums[name] = senum
+		}
+	}
+	return sroot
+}
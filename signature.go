@@ -0,0 +1,454 @@
+package rbxdump
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// SignatureError indicates that a signature could not be parsed.
+type SignatureError interface {
+	error
+	// SignatureError returns an error message and the byte offset within the
+	// signature at which the error occurred.
+	SignatureError() (msg string, offset int)
+}
+
+// errSignature implements the SignatureError interface.
+type errSignature struct {
+	msg    string
+	offset int
+}
+
+func (err errSignature) Error() string {
+	return "signature error at offset " + strconv.Itoa(err.offset) + ": " + err.msg
+}
+
+func (err errSignature) SignatureError() (msg string, offset int) {
+	return err.msg, err.offset
+}
+
+// Signature describes the parameters and return types of a function, event,
+// or callback.
+type Signature struct {
+	Parameters []Parameter
+	// Returns is empty if nothing is returned. A single void type is
+	// equivalent to an empty list.
+	Returns []Type
+}
+
+// MemberSignature returns the signature of a Function, Event, or Callback.
+// Returns false if the member has no signature.
+func MemberSignature(member Member) (sig Signature, ok bool) {
+	switch member := member.(type) {
+	case *Function:
+		return Signature{Parameters: member.Parameters, Returns: member.ReturnType}, true
+	case *Event:
+		return Signature{Parameters: member.Parameters}, true
+	case *Callback:
+		return Signature{Parameters: member.Parameters, Returns: member.ReturnType}, true
+	}
+	return sig, false
+}
+
+// LuauType returns the Luau spelling of a type. Numeric types are spelled as
+// "number", enums as "Enum.Name", and groups as the Luau type with equivalent
+// structure. The void type is spelled as "()".
+func LuauType(typ Type) string {
+	var s string
+	switch typ.Kind() {
+	case KindVoid:
+		return "()"
+	case KindTuple:
+		return "...any"
+	case KindVariant:
+		return "any"
+	case KindArray:
+		s = "{any}"
+	case KindDictionary:
+		s = "{[string]: any}"
+	case KindMap:
+		s = "{[any]: any}"
+	case KindObjects:
+		s = "{Instance}"
+	default:
+		switch {
+		case typ.IsNumber():
+			s = "number"
+		case typ.Name == "bool":
+			s = "boolean"
+		case typ.Name == "null":
+			return "nil"
+		case typ.Category == CategoryEnum:
+			s = "Enum." + typ.Name
+		default:
+			s = typ.Name
+		}
+	}
+	if typ.Optional {
+		s += "?"
+	}
+	return s
+}
+
+// luauTables maps the compacted Luau spelling of each table type to the type
+// it represents.
+var luauTables = map[string]Type{
+	"{any}":          {Category: CategoryGroup, Name: "Array"},
+	"{[string]:any}": {Category: CategoryGroup, Name: "Dictionary"},
+	"{[any]:any}":    {Category: CategoryGroup, Name: "Map"},
+	"{Instance}":     {Category: CategoryGroup, Name: "Objects"},
+}
+
+// luauNames maps Luau type names to the type they represent.
+var luauNames = map[string]Type{
+	"number":  {Category: CategoryPrimitive, Name: "double"},
+	"boolean": {Category: CategoryPrimitive, Name: "bool"},
+	"string":  {Category: CategoryPrimitive, Name: "string"},
+	"nil":     {Category: CategoryPrimitive, Name: "null"},
+	"any":     {Category: CategoryGroup, Name: "Variant"},
+}
+
+// String returns the signature in Luau function type syntax, such as
+// "(a: number, b: string?) -> boolean". Types are spelled as by LuauType. The
+// default value of an optional parameter follows its type, as in
+// "(a: number = 1) -> ()".
+func (sig Signature) String() string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, param := range sig.Parameters {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(param.Name)
+		b.WriteString(": ")
+		b.WriteString(LuauType(param.Type))
+		if param.Optional {
+			b.WriteString(" = ")
+			b.WriteString(param.Default)
+		}
+	}
+	b.WriteString(") -> ")
+	switch returns := sig.returns(); len(returns) {
+	case 0:
+		b.WriteString("()")
+	case 1:
+		b.WriteString(LuauType(returns[0]))
+	default:
+		b.WriteByte('(')
+		for i, typ := range returns {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(LuauType(typ))
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// returns returns the return types of the signature, excluding void.
+func (sig Signature) returns() []Type {
+	if len(sig.Returns) == 1 && sig.Returns[0].Kind() == KindVoid {
+		return nil
+	}
+	return sig.Returns
+}
+
+// ParseSignature parses a signature written in the syntax produced by
+// Signature.String. The return arrow may be omitted when nothing is returned.
+//
+// Types written with Luau spellings are converted to the corresponding type;
+// "number" becomes the double type. Names of primitive types, such as "int",
+// are also accepted. Other names, such as classes and data types, have no
+// category; see Root.CategorizeType. Returns a SignatureError if the signature
+// is malformed.
+func ParseSignature(s string) (sig Signature, err error) {
+	p := sigParser{s: s}
+	if !p.accept("(") {
+		return sig, p.fail("expected '('")
+	}
+	if !p.accept(")") {
+		for {
+			var param Parameter
+			var ok bool
+			if param.Name, ok = p.ident(); !ok {
+				return sig, p.fail("expected parameter name")
+			}
+			if !p.accept(":") {
+				return sig, p.fail("expected ':'")
+			}
+			if param.Type, err = p.typ(); err != nil {
+				return sig, err
+			}
+			if p.accept("=") {
+				param.Optional = true
+				if param.Default = p.value(); param.Default == "" {
+					return sig, p.fail("expected default value")
+				}
+			}
+			sig.Parameters = append(sig.Parameters, param)
+			if p.accept(",") {
+				continue
+			}
+			if p.accept(")") {
+				break
+			}
+			return sig, p.fail("expected ',' or ')'")
+		}
+	}
+	if p.accept("->") {
+		if p.accept("(") {
+			if !p.accept(")") {
+				for {
+					typ, err := p.typ()
+					if err != nil {
+						return sig, err
+					}
+					sig.Returns = append(sig.Returns, typ)
+					if p.accept(",") {
+						continue
+					}
+					if p.accept(")") {
+						break
+					}
+					return sig, p.fail("expected ',' or ')'")
+				}
+			}
+		} else {
+			typ, err := p.typ()
+			if err != nil {
+				return sig, err
+			}
+			sig.Returns = []Type{typ}
+		}
+	}
+	p.skipSpace()
+	if p.i < len(p.s) {
+		return sig, p.fail("unexpected '" + string(p.s[p.i]) + "'")
+	}
+	return sig, nil
+}
+
+// sigParser holds the state of a signature being parsed.
+type sigParser struct {
+	s string
+	i int
+}
+
+func (p *sigParser) fail(msg string) error {
+	return errSignature{msg: msg, offset: p.i}
+}
+
+func (p *sigParser) skipSpace() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+// accept consumes tok if it appears next, skipping leading space.
+func (p *sigParser) accept(tok string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.s[p.i:], tok) {
+		p.i += len(tok)
+		return true
+	}
+	return false
+}
+
+// ident consumes an identifier, skipping leading space.
+func (p *sigParser) ident() (string, bool) {
+	p.skipSpace()
+	j := p.i
+	for j < len(p.s) {
+		c := p.s[j]
+		if c == '_' || 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || j > p.i && '0' <= c && c <= '9' {
+			j++
+			continue
+		}
+		break
+	}
+	if j == p.i {
+		return "", false
+	}
+	s := p.s[p.i:j]
+	p.i = j
+	return s, true
+}
+
+// typ consumes a type, followed by an optional "?".
+func (p *sigParser) typ() (typ Type, err error) {
+	p.skipSpace()
+	switch {
+	case p.accept("..."):
+		if _, ok := p.ident(); !ok {
+			return typ, p.fail("expected type name")
+		}
+		return Type{Category: CategoryGroup, Name: "Tuple"}, nil
+	case p.accept("()"):
+		return Type{Category: CategoryPrimitive, Name: "void"}, nil
+	case strings.HasPrefix(p.s[p.i:], "{"):
+		end := strings.IndexByte(p.s[p.i:], '}')
+		if end < 0 {
+			return typ, p.fail("unterminated table type")
+		}
+		table := strings.Join(strings.Fields(p.s[p.i:p.i+end+1]), "")
+		t, ok := luauTables[table]
+		if !ok {
+			return typ, p.fail("unsupported table type")
+		}
+		typ = t
+		p.i += end + 1
+	default:
+		name, ok := p.ident()
+		if !ok {
+			return typ, p.fail("expected type")
+		}
+		for strings.HasPrefix(p.s[p.i:], ".") {
+			p.i++
+			next, ok := p.ident()
+			if !ok {
+				return typ, p.fail("expected type name")
+			}
+			name += "." + next
+		}
+		if t, ok := luauNames[name]; ok {
+			typ = t
+		} else if enum, ok := strings.CutPrefix(name, "Enum."); ok {
+			typ = Type{Category: CategoryEnum, Name: enum}
+		} else if category, ok := InferCategory(name); ok {
+			typ = Type{Category: category, Name: name}
+		} else {
+			typ = Type{Name: name}
+		}
+	}
+	if strings.HasPrefix(p.s[p.i:], "?") {
+		p.i++
+		typ.Optional = true
+	}
+	return typ, nil
+}
+
+// value consumes a default value, which extends to the next ',' or ')' that
+// is not enclosed within brackets or a string.
+func (p *sigParser) value() string {
+	p.skipSpace()
+	start := p.i
+	depth := 0
+	for ; p.i < len(p.s); p.i++ {
+		switch c := p.s[p.i]; c {
+		case '(', '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		case ')':
+			if depth == 0 {
+				return strings.TrimSpace(p.s[start:p.i])
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return strings.TrimSpace(p.s[start:p.i])
+			}
+		case '"', '\'':
+			for p.i++; p.i < len(p.s) && p.s[p.i] != c; p.i++ {
+				if p.s[p.i] == '\\' {
+					p.i++
+				}
+			}
+		}
+	}
+	return strings.TrimSpace(p.s[start:])
+}
+
+// isRequired returns whether an argument must be passed for the parameter.
+func isRequired(param Parameter) bool {
+	return !param.Optional && !param.Type.Optional && param.Type.Kind() != KindTuple
+}
+
+// MinArity returns the minimum number of arguments that must be passed to
+// satisfy the signature. Trailing parameters that are optional, have an
+// optional type, or are a Tuple may be omitted.
+func (sig Signature) MinArity() int {
+	n := 0
+	for i, param := range sig.Parameters {
+		if isRequired(param) {
+			n = i + 1
+		}
+	}
+	return n
+}
+
+// MaxArity returns the maximum number of arguments accepted by the signature.
+// Returns -1 if the last parameter is a Tuple, which accepts any number of
+// arguments.
+func (sig Signature) MaxArity() int {
+	if n := len(sig.Parameters); n > 0 && sig.Parameters[n-1].Type.Kind() == KindTuple {
+		return -1
+	}
+	return len(sig.Parameters)
+}
+
+// AcceptsArity returns whether the signature may be called with n arguments.
+func (sig Signature) AcceptsArity(n int) bool {
+	maxN := sig.MaxArity()
+	return n >= sig.MinArity() && (maxN < 0 || n <= maxN)
+}
+
+// baseType returns the Luau spelling of a type, ignoring whether it is
+// optional.
+func baseType(typ Type) string {
+	typ.Optional = false
+	return LuauType(typ)
+}
+
+// Compatible returns an error describing the first change from sig to next
+// that would break existing uses of sig, or nil if next is compatible. Types
+// are compared by their Luau spelling.
+//
+// Parameters are compared by position. A parameter is incompatible if its name
+// or type changes, if it no longer accepts nil, or if it becomes required.
+// Removed parameters, and added parameters that are required, are
+// incompatible. Reordering parameters is therefore incompatible, whereas
+// adding an optional parameter is compatible.
+//
+// Return values are compared by position. A return value is incompatible if
+// its type changes, or if it may become nil. Removed return values are
+// incompatible.
+func (sig Signature) Compatible(next Signature) error {
+	for i, p := range sig.Parameters {
+		if i >= len(next.Parameters) {
+			return errors.New("parameter \"" + p.Name + "\" was removed")
+		}
+		n := next.Parameters[i]
+		switch {
+		case n.Name != p.Name:
+			return errors.New("parameter " + strconv.Itoa(i+1) + " changed from \"" + p.Name + "\" to \"" + n.Name + "\"")
+		case baseType(n.Type) != baseType(p.Type):
+			return errors.New("type of parameter \"" + p.Name + "\" changed from " + baseType(p.Type) + " to " + baseType(n.Type))
+		case p.Type.Optional && !n.Type.Optional:
+			return errors.New("parameter \"" + p.Name + "\" no longer accepts nil")
+		case !isRequired(p) && isRequired(n):
+			return errors.New("parameter \"" + p.Name + "\" became required")
+		}
+	}
+	for _, n := range next.Parameters[min(len(sig.Parameters), len(next.Parameters)):] {
+		if isRequired(n) {
+			return errors.New("required parameter \"" + n.Name + "\" was added")
+		}
+	}
+	prev, nrets := sig.returns(), next.returns()
+	for i, p := range prev {
+		if i >= len(nrets) {
+			return errors.New("return value " + strconv.Itoa(i+1) + " was removed")
+		}
+		n := nrets[i]
+		switch {
+		case baseType(n) != baseType(p):
+			return errors.New("type of return value " + strconv.Itoa(i+1) + " changed from " + baseType(p) + " to " + baseType(n))
+		case !p.Optional && n.Optional:
+			return errors.New("return value " + strconv.Itoa(i+1) + " may be nil")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package rbxdump
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Stats summarizes the contents of a Root.
+type Stats struct {
+	// Classes is the number of classes.
+	Classes int
+	// Members maps each member type to the number of members of that type.
+	Members map[string]int
+	// Enums is the number of enums.
+	Enums int
+	// EnumItems is the number of enum items.
+	EnumItems int
+
+	// Tags maps each tag to the number of elements that have the tag.
+	Tags map[string]int
+	// Security maps each security level to the number of functions, events,
+	// and callbacks that have the level.
+	Security map[string]int
+	// ReadSecurity maps each security level to the number of properties that
+	// have the level as their ReadSecurity.
+	ReadSecurity map[string]int
+	// WriteSecurity maps each security level to the number of properties that
+	// have the level as their WriteSecurity.
+	WriteSecurity map[string]int
+	// ThreadSafety maps each thread safety value to the number of members
+	// that have the value.
+	ThreadSafety map[string]int
+	// MemoryCategory maps each memory category to the number of classes that
+	// have the category.
+	MemoryCategory map[string]int
+	// Deprecated maps each kind of element, as described by Path.Element, to
+	// the number of such elements that have a PreferredDescriptor.
+	Deprecated map[string]int
+
+	// MaxDepth is the greatest number of ancestors of any class.
+	MaxDepth int
+	// DeepestChains contains the inheritance chain of each class that has
+	// MaxDepth ancestors, ordered from the root of the tree to the class.
+	// Chains are ordered by the name of the last class.
+	DeepestChains [][]string
+}
+
+// NewStats returns the Stats of root.
+func NewStats(root *Root) *Stats {
+	s := &Stats{
+		Members:        map[string]int{},
+		Tags:           map[string]int{},
+		Security:       map[string]int{},
+		ReadSecurity:   map[string]int{},
+		WriteSecurity:  map[string]int{},
+		ThreadSafety:   map[string]int{},
+		MemoryCategory: map[string]int{},
+		Deprecated:     map[string]int{},
+	}
+	countTags := func(tags Tags) {
+		for _, tag := range tagSet(tags) {
+			s.Tags[tag]++
+		}
+	}
+	countDeprecated := func(element string, pd PreferredDescriptor) {
+		if pd != (PreferredDescriptor{}) {
+			s.Deprecated[element]++
+		}
+	}
+	for _, class := range root.Classes {
+		if class == nil {
+			continue
+		}
+		s.Classes++
+		s.MemoryCategory[class.MemoryCategory]++
+		countTags(class.Tags)
+		countDeprecated("Class", class.PreferredDescriptor)
+		for _, member := range class.Members {
+			if member == nil {
+				continue
+			}
+			s.Members[member.MemberType()]++
+			countTags(member.GetTags())
+			switch member := member.(type) {
+			case *Property:
+				s.ReadSecurity[member.ReadSecurity]++
+				s.WriteSecurity[member.WriteSecurity]++
+				s.ThreadSafety[member.ThreadSafety]++
+				countDeprecated("Property", member.PreferredDescriptor)
+			case *Function:
+				s.Security[member.Security]++
+				s.ThreadSafety[member.ThreadSafety]++
+				countDeprecated("Function", member.PreferredDescriptor)
+			case *Event:
+				s.Security[member.Security]++
+				s.ThreadSafety[member.ThreadSafety]++
+				countDeprecated("Event", member.PreferredDescriptor)
+			case *Callback:
+				s.Security[member.Security]++
+				s.ThreadSafety[member.ThreadSafety]++
+				countDeprecated("Callback", member.PreferredDescriptor)
+			}
+		}
+	}
+	for _, enum := range root.Enums {
+		if enum == nil {
+			continue
+		}
+		s.Enums++
+		countTags(enum.Tags)
+		countDeprecated("Enum", enum.PreferredDescriptor)
+		for _, item := range enum.Items {
+			if item == nil {
+				continue
+			}
+			s.EnumItems++
+			countTags(item.Tags)
+			countDeprecated("EnumItem", item.PreferredDescriptor)
+		}
+	}
+
+	h := NewHierarchy(root)
+	for _, name := range h.Leaves() {
+		switch depth := h.Depth(name); {
+		case depth < s.MaxDepth:
+			continue
+		case depth > s.MaxDepth:
+			s.MaxDepth = depth
+			s.DeepestChains = nil
+		}
+		chain := h.Ancestors(name)
+		slices.Reverse(chain)
+		chain = append(chain, name)
+		s.DeepestChains = append(s.DeepestChains, chain)
+	}
+	return s
+}
+
+// MemberCount returns the total number of members.
+func (s *Stats) MemberCount() int {
+	n := 0
+	for _, count := range s.Members {
+		n += count
+	}
+	return n
+}
+
+// writeCounts writes a section listing each entry of counts, ordered by
+// descending count, then by key.
+func writeCounts(b *strings.Builder, title string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	keys := sortedKeys(counts)
+	slices.SortStableFunc(keys, func(a, b string) int {
+		return counts[b] - counts[a]
+	})
+	b.WriteString(title + ":\n")
+	for _, key := range keys {
+		name := key
+		if name == "" {
+			name = "(none)"
+		}
+		b.WriteString("\t" + name + ": " + strconv.Itoa(counts[key]) + "\n")
+	}
+}
+
+// String returns a human-readable report of the stats.
+func (s *Stats) String() string {
+	var b strings.Builder
+	b.WriteString("Classes: " + strconv.Itoa(s.Classes) + "\n")
+	b.WriteString("Members: " + strconv.Itoa(s.MemberCount()) + "\n")
+	for _, typ := range []string{"Property", "Function", "Event", "Callback"} {
+		b.WriteString("\t" + typ + ": " + strconv.Itoa(s.Members[typ]) + "\n")
+	}
+	b.WriteString("Enums: " + strconv.Itoa(s.Enums) + "\n")
+	b.WriteString("EnumItems: " + strconv.Itoa(s.EnumItems) + "\n")
+	writeCounts(&b, "Tags", s.Tags)
+	writeCounts(&b, "Security", s.Security)
+	writeCounts(&b, "ReadSecurity", s.ReadSecurity)
+	writeCounts(&b, "WriteSecurity", s.WriteSecurity)
+	writeCounts(&b, "ThreadSafety", s.ThreadSafety)
+	writeCounts(&b, "MemoryCategory", s.MemoryCategory)
+	writeCounts(&b, "Deprecated", s.Deprecated)
+	b.WriteString("MaxDepth: " + strconv.Itoa(s.MaxDepth) + "\n")
+	for _, chain := range s.DeepestChains {
+		b.WriteString("\t" + strings.Join(chain, " > ") + "\n")
+	}
+	return b.String()
+}
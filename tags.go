@@ -0,0 +1,113 @@
+package rbxdump
+
+import (
+	"slices"
+	"sort"
+)
+
+// Names of tags known to appear in API dumps.
+const (
+	TagCanYield         = "CanYield"
+	TagCustomLuaState   = "CustomLuaState"
+	TagDeprecated       = "Deprecated"
+	TagHidden           = "Hidden"
+	TagNoYield          = "NoYield"
+	TagNotBrowsable     = "NotBrowsable"
+	TagNotCreatable     = "NotCreatable"
+	TagNotReplicated    = "NotReplicated"
+	TagNotScriptable    = "NotScriptable"
+	TagPlayerReplicated = "PlayerReplicated"
+	TagReadOnly         = "ReadOnly"
+	TagService          = "Service"
+	TagSettings         = "Settings"
+	TagUserSettings     = "UserSettings"
+	TagYields           = "Yields"
+)
+
+// TagInfo describes a known tag.
+type TagInfo struct {
+	// Name is the name of the tag.
+	Name string
+	// Elements lists the kinds of element to which the tag applies, as
+	// described by Path.Element.
+	Elements []string
+	// Description briefly describes the meaning of the tag.
+	Description string
+}
+
+// AppliesTo returns whether the tag applies to the given kind of element, as
+// described by Path.Element.
+func (info TagInfo) AppliesTo(element string) bool {
+	return slices.Contains(info.Elements, element)
+}
+
+var (
+	anyElement  = []string{"Class", "Property", "Function", "Event", "Callback", "Enum", "EnumItem"}
+	anyMember   = []string{"Property", "Function", "Event", "Callback"}
+	anyCallable = []string{"Function", "Callback"}
+)
+
+// tagRegistry contains the known tags.
+var tagRegistry = map[string]TagInfo{
+	TagCanYield:         {TagCanYield, anyCallable, "The member may yield, but is not required to."},
+	TagCustomLuaState:   {TagCustomLuaState, []string{"Function"}, "The function is called with a custom Lua state."},
+	TagDeprecated:       {TagDeprecated, anyElement, "The element is deprecated, and should not be used in new work."},
+	TagHidden:           {TagHidden, anyMember, "The member is hidden from documentation and the property browser."},
+	TagNoYield:          {TagNoYield, anyCallable, "The member must not yield."},
+	TagNotBrowsable:     {TagNotBrowsable, anyElement, "The element is not shown in the object browser."},
+	TagNotCreatable:     {TagNotCreatable, []string{"Class"}, "The class cannot be created with Instance.new."},
+	TagNotReplicated:    {TagNotReplicated, []string{"Class", "Property"}, "The element is not replicated between server and client."},
+	TagNotScriptable:    {TagNotScriptable, anyMember, "The member cannot be accessed by scripts."},
+	TagPlayerReplicated: {TagPlayerReplicated, []string{"Class"}, "Instances of the class are replicated only to a particular player."},
+	TagReadOnly:         {TagReadOnly, []string{"Property"}, "The property cannot be assigned to."},
+	TagService:          {TagService, []string{"Class"}, "The class is a service, retrieved with GetService."},
+	TagSettings:         {TagSettings, []string{"Class"}, "The class is a settings object, found under settings()."},
+	TagUserSettings:     {TagUserSettings, []string{"Class"}, "The class is a user settings object, found under UserSettings()."},
+	TagYields:           {TagYields, []string{"Function"}, "The function yields the current thread."},
+}
+
+// LookupTag returns information about the known tag of the given name.
+// Returns false if the tag is not known.
+func LookupTag(name string) (info TagInfo, ok bool) {
+	info, ok = tagRegistry[name]
+	info.Elements = slices.Clone(info.Elements)
+	return info, ok
+}
+
+// KnownTags returns information about every known tag, ordered by name.
+func KnownTags() []TagInfo {
+	list := make([]TagInfo, 0, len(tagRegistry))
+	for _, info := range tagRegistry {
+		info.Elements = slices.Clone(info.Elements)
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// IsDeprecated returns whether the Deprecated tag is present.
+func (tags Tags) IsDeprecated() bool { return tags.GetTag(TagDeprecated) }
+
+// IsHidden returns whether the Hidden tag is present.
+func (tags Tags) IsHidden() bool { return tags.GetTag(TagHidden) }
+
+// IsNotBrowsable returns whether the NotBrowsable tag is present.
+func (tags Tags) IsNotBrowsable() bool { return tags.GetTag(TagNotBrowsable) }
+
+// IsNotCreatable returns whether the NotCreatable tag is present.
+func (tags Tags) IsNotCreatable() bool { return tags.GetTag(TagNotCreatable) }
+
+// IsNotReplicated returns whether the NotReplicated tag is present.
+func (tags Tags) IsNotReplicated() bool { return tags.GetTag(TagNotReplicated) }
+
+// IsNotScriptable returns whether the NotScriptable tag is present.
+func (tags Tags) IsNotScriptable() bool { return tags.GetTag(TagNotScriptable) }
+
+// IsReadOnly returns whether the ReadOnly tag is present.
+func (tags Tags) IsReadOnly() bool { return tags.GetTag(TagReadOnly) }
+
+// IsService returns whether the Service tag is present.
+func (tags Tags) IsService() bool { return tags.GetTag(TagService) }
+
+// Yields returns whether the Yields tag is present.
+func (tags Tags) Yields() bool { return tags.GetTag(TagYields) }
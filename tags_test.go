@@ -0,0 +1,21 @@
+package rbxdump
+
+import (
+	"testing"
+)
+
+func TestTagRegistry(t *testing.T) {
+	for name, info := range tagRegistry {
+		if info.Name != name {
+			t.Errorf("tag %s registered with name %s", name, info.Name)
+		}
+		if len(info.Elements) == 0 {
+			t.Errorf("tag %s applies to no elements", name)
+		}
+		for _, element := range info.Elements {
+			if !(TagInfo{Elements: anyElement}).AppliesTo(element) {
+				t.Errorf("tag %s applies to unknown element %s", name, element)
+			}
+		}
+	}
+}
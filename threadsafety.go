@@ -0,0 +1,191 @@
+package rbxdump
+
+import (
+	"sort"
+)
+
+// ThreadSafety indicates whether a member may be used from parallel Luau.
+// Levels are ordered, such that a greater level is safer.
+type ThreadSafety int
+
+const (
+	ThreadUnsafe   ThreadSafety = iota // Unsafe: usable only from serial execution.
+	ThreadReadSafe                     // ReadSafe: may be read from parallel execution.
+	ThreadSafe                         // Safe: may be used from parallel execution.
+)
+
+// ParseThreadSafety returns the ThreadSafety corresponding to a thread safety
+// value as it appears in API dumps. Returns ThreadUnsafe and false if the
+// value is not known, including when it is empty.
+func ParseThreadSafety(s string) (t ThreadSafety, ok bool) {
+	switch s {
+	case "Unsafe":
+		return ThreadUnsafe, true
+	case "ReadSafe":
+		return ThreadReadSafe, true
+	case "Safe":
+		return ThreadSafe, true
+	}
+	return ThreadUnsafe, false
+}
+
+// String returns the thread safety as it appears in API dumps.
+func (t ThreadSafety) String() string {
+	switch t {
+	case ThreadUnsafe:
+		return "Unsafe"
+	case ThreadReadSafe:
+		return "ReadSafe"
+	case ThreadSafe:
+		return "Safe"
+	}
+	return "<invalid>"
+}
+
+// memberThreadSafety returns the ThreadSafety field of a member.
+func memberThreadSafety(member Member) string {
+	switch member := member.(type) {
+	case *Property:
+		return member.ThreadSafety
+	case *Function:
+		return member.ThreadSafety
+	case *Event:
+		return member.ThreadSafety
+	case *Callback:
+		return member.ThreadSafety
+	}
+	return ""
+}
+
+// memberPreferredDescriptor returns the PreferredDescriptor field of a
+// member.
+func memberPreferredDescriptor(member Member) PreferredDescriptor {
+	switch member := member.(type) {
+	case *Property:
+		return member.PreferredDescriptor
+	case *Function:
+		return member.PreferredDescriptor
+	case *Event:
+		return member.PreferredDescriptor
+	case *Callback:
+		return member.PreferredDescriptor
+	}
+	return PreferredDescriptor{}
+}
+
+// MemberThreadSafety classifies the thread safety of a member. A member with
+// an unknown or missing thread safety is classified as ThreadUnsafe.
+func MemberThreadSafety(member Member) ThreadSafety {
+	t, _ := ParseThreadSafety(memberThreadSafety(member))
+	return t
+}
+
+// ClassThreadSafety summarizes the thread safety of the members accessible
+// through a class.
+type ClassThreadSafety struct {
+	// Class is the name of the class.
+	Class string
+	// Members maps the name of each member accessible through the class,
+	// including inherited members, to its thread safety.
+	Members map[string]ThreadSafety
+}
+
+// Level returns the lowest thread safety among the members of the class. That
+// is, ThreadSafe indicates that every member may be used from parallel
+// execution. A class without members is ThreadSafe.
+func (c ClassThreadSafety) Level() ThreadSafety {
+	level := ThreadSafe
+	for _, t := range c.Members {
+		level = min(level, t)
+	}
+	return level
+}
+
+// Count returns the number of members that have the given thread safety.
+func (c ClassThreadSafety) Count(t ThreadSafety) int {
+	n := 0
+	for _, m := range c.Members {
+		if m == t {
+			n++
+		}
+	}
+	return n
+}
+
+// List returns the names of members that have the given thread safety,
+// ordered by name.
+func (c ClassThreadSafety) List(t ThreadSafety) []string {
+	var list []string
+	for _, name := range sortedKeys(c.Members) {
+		if c.Members[name] == t {
+			list = append(list, name)
+		}
+	}
+	return list
+}
+
+// ClassThreadSafety returns a summary of the thread safety of the members
+// accessible through the class of the given name, including inherited members.
+// Returns false if the class does not exist.
+//
+// If the superclass chain is broken or cyclic, then the members resolved so far
+// are summarized, and a SuperclassError is returned.
+func (root *Root) ClassThreadSafety(class string) (c ClassThreadSafety, ok bool, err error) {
+	if root.Classes[class] == nil {
+		return c, false, nil
+	}
+	members, err := root.GetAllMembers(class)
+	c = ClassThreadSafety{Class: class, Members: make(map[string]ThreadSafety, len(members))}
+	for _, m := range members {
+		c.Members[m.Member.MemberName()] = MemberThreadSafety(m.Member)
+	}
+	return c, true, err
+}
+
+// ThreadSafetyMismatch describes a deprecated member whose preferred
+// replacement has a different thread safety.
+type ThreadSafetyMismatch struct {
+	// Path locates the deprecated member.
+	Path Path
+	// ThreadSafety is the thread safety of the deprecated member.
+	ThreadSafety ThreadSafety
+	// Preferred is the name of the replacement member.
+	Preferred string
+	// PreferredThreadSafety is the thread safety of the replacement.
+	PreferredThreadSafety ThreadSafety
+}
+
+// ThreadSafetyMismatches returns each member whose PreferredDescriptor refers
+// to a replacement with a different thread safety, ordered by location. The
+// thread safety of the replacement is taken from the ThreadSafety of the
+// PreferredDescriptor if present, or otherwise from the replacement member, as
+// resolved by LookupMember. Replacements that cannot be resolved are skipped.
+func (root *Root) ThreadSafetyMismatches() []ThreadSafetyMismatch {
+	var list []ThreadSafetyMismatch
+	for class := range root.IterClasses() {
+		for member := range class.IterMembers() {
+			pd := memberPreferredDescriptor(member)
+			if pd.Name == "" {
+				continue
+			}
+			preferred, ok := ParseThreadSafety(pd.ThreadSafety)
+			if !ok {
+				m, _, _ := root.LookupMember(class.Name, pd.Name)
+				if m == nil {
+					continue
+				}
+				preferred = MemberThreadSafety(m)
+			}
+			if t := MemberThreadSafety(member); t != preferred {
+				list = append(list, ThreadSafetyMismatch{
+					Path:                  MemberPath(class.Name, member),
+					ThreadSafety:          t,
+					Preferred:             pd.Name,
+					PreferredThreadSafety: preferred,
+				})
+			}
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Path.Less(list[j].Path) })
+	return list
+}
@@ -0,0 +1,169 @@
+package rbxdump
+
+import (
+	"strings"
+)
+
+// Categories of types known to appear in API dumps.
+const (
+	CategoryPrimitive = "Primitive"
+	CategoryClass     = "Class"
+	CategoryDataType  = "DataType"
+	CategoryEnum      = "Enum"
+	CategoryGroup     = "Group"
+)
+
+// TypeKind describes the structure of the values of a type.
+type TypeKind int
+
+const (
+	KindSingle     TypeKind = iota // A single value of the named type.
+	KindVoid                       // void: no value.
+	KindArray                      // Array: a list of values of any type.
+	KindDictionary                 // Dictionary: a table of values with string keys.
+	KindMap                        // Map: a table of values with keys of any type.
+	KindTuple                      // Tuple: any number of values of any type.
+	KindVariant                    // Variant: a single value of any type.
+	KindObjects                    // Objects: a list of instances.
+)
+
+// String returns a string representation of the kind.
+func (k TypeKind) String() string {
+	switch k {
+	case KindSingle:
+		return "Single"
+	case KindVoid:
+		return "Void"
+	case KindArray:
+		return "Array"
+	case KindDictionary:
+		return "Dictionary"
+	case KindMap:
+		return "Map"
+	case KindTuple:
+		return "Tuple"
+	case KindVariant:
+		return "Variant"
+	case KindObjects:
+		return "Objects"
+	}
+	return "<invalid>"
+}
+
+// typeKinds maps the name of each type that is not KindSingle to its kind.
+var typeKinds = map[string]TypeKind{
+	"void":       KindVoid,
+	"Array":      KindArray,
+	"Dictionary": KindDictionary,
+	"Map":        KindMap,
+	"Tuple":      KindTuple,
+	"Variant":    KindVariant,
+	"Objects":    KindObjects,
+}
+
+// primitiveTypes contains the names of primitive types.
+var primitiveTypes = map[string]bool{
+	"bool":   true,
+	"double": true,
+	"float":  true,
+	"int":    true,
+	"int64":  true,
+	"null":   true,
+	"string": true,
+	"void":   true,
+}
+
+// groupTypes contains the names of group types.
+var groupTypes = map[string]bool{
+	"Array":      true,
+	"Dictionary": true,
+	"Map":        true,
+	"Objects":    true,
+	"Tuple":      true,
+	"Variant":    true,
+}
+
+// Kind returns the structure of the values of the type, as determined by its
+// name.
+func (typ Type) Kind() TypeKind {
+	return typeKinds[typ.Name]
+}
+
+// IsNumber returns whether the type is a primitive numeric type.
+func (typ Type) IsNumber() bool {
+	switch typ.Name {
+	case "int", "int64", "float", "double":
+		return typ.Category == "" || typ.Category == CategoryPrimitive
+	}
+	return false
+}
+
+// ParseType parses a type from its string representation, as returned by
+// Type.String. The representation has the form "Category:Name?", where the
+// category and the "?" suffix, indicating an optional type, may be omitted.
+// Returns false if the representation is malformed.
+func ParseType(s string) (typ Type, ok bool) {
+	if name, ok := strings.CutSuffix(s, "?"); ok {
+		s = name
+		typ.Optional = true
+	}
+	if category, name, ok := strings.Cut(s, ":"); ok {
+		if !isTypeName(category) {
+			return Type{}, false
+		}
+		typ.Category = category
+		s = name
+	}
+	if !isTypeName(s) {
+		return Type{}, false
+	}
+	typ.Name = s
+	return typ, true
+}
+
+// isTypeName returns whether s is a valid category or type name.
+func isTypeName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch c {
+		case ':', '?', ' ', '\t', '\n', '\r':
+			return false
+		}
+	}
+	return true
+}
+
+// InferCategory returns the category of a type of the given name, for names
+// that can be categorized without referring to a Root; that is, primitive and
+// group types. Returns false if the category cannot be inferred.
+func InferCategory(name string) (category string, ok bool) {
+	switch {
+	case primitiveTypes[name]:
+		return CategoryPrimitive, true
+	case groupTypes[name]:
+		return CategoryGroup, true
+	}
+	return "", false
+}
+
+// CategorizeType returns typ with its category filled in, if the category is
+// empty, as is the case for types decoded from the legacy format. The category
+// is inferred with InferCategory, then by looking up a class or enum of the
+// type's name in the root. Otherwise, the type is assumed to be a DataType.
+func (root *Root) CategorizeType(typ Type) Type {
+	if typ.Category != "" {
+		return typ
+	}
+	if category, ok := InferCategory(typ.Name); ok {
+		typ.Category = category
+	} else if root.Classes[typ.Name] != nil {
+		typ.Category = CategoryClass
+	} else if root.Enums[typ.Name] != nil {
+		typ.Category = CategoryEnum
+	} else {
+		typ.Category = CategoryDataType
+	}
+	return typ
+}
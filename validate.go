@@ -0,0 +1,280 @@
+package rbxdump
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Problem describes a semantic problem with an element of a Root.
+type Problem struct {
+	// Path locates the element containing the problem.
+	Path Path
+	// Field is the name of the field containing the problem, if applicable.
+	Field string
+	// Msg describes the problem.
+	Msg string
+}
+
+// Error implements the error interface.
+func (p Problem) Error() string {
+	if p.Field == "" {
+		return p.Path.String() + ": " + p.Msg
+	}
+	return p.Path.String() + ": " + p.Field + ": " + p.Msg
+}
+
+// sortProblems sorts Problem values by path, then field. The order of problems
+// with equal locations is preserved when sorted stably.
+type sortProblems []Problem
+
+func (a sortProblems) Len() int      { return len(a) }
+func (a sortProblems) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a sortProblems) Less(i, j int) bool {
+	if a[i].Path != a[j].Path {
+		return a[i].Path.Less(a[j].Path)
+	}
+	return a[i].Field < a[j].Field
+}
+
+// Validate checks a Root for semantic problems that are not prevented by the
+// structure of the data model, such as references to elements that do not
+// exist.
+type Validate struct {
+	Root *Root
+	// CheckTags indicates whether tags are checked. If so, then a tag is
+	// reported if it is not known, or if it does not apply to the kind of
+	// element that has it. See LookupTag.
+	CheckTags bool
+	// CheckDefaults indicates whether default values are checked. If so, then
+	// the default of a property or optional parameter is reported if it does
+	// not match the declared type, or if it refers to an enum item that does
+	// not exist. See ParseDefault.
+	CheckDefaults bool
+}
+
+// validator holds the state of a validation pass.
+type validator struct {
+	root          *Root
+	checkTags     bool
+	checkDefaults bool
+	problems      []Problem
+}
+
+func (v *validator) report(path Path, field, msg string) {
+	v.problems = append(v.problems, Problem{Path: path, Field: field, Msg: msg})
+}
+
+// Validate returns every problem found within the root, ordered by location.
+func (v Validate) Validate() []Problem {
+	if v.Root == nil {
+		return nil
+	}
+	s := validator{root: v.Root, checkTags: v.CheckTags, checkDefaults: v.CheckDefaults}
+	s.validate()
+	sort.Stable(sortProblems(s.problems))
+	return s.problems
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (v *validator) validate() {
+	for _, name := range sortedKeys(v.root.Classes) {
+		v.validateClass(name, v.root.Classes[name])
+	}
+	for _, name := range sortedKeys(v.root.Enums) {
+		v.validateEnum(name, v.root.Enums[name])
+	}
+}
+
+func (v *validator) validateClass(name string, class *Class) {
+	path := ClassPath(name)
+	if class == nil {
+		v.report(path, "", "class is nil")
+		return
+	}
+	if class.Name != name {
+		v.report(path, "Name", "name \""+class.Name+"\" does not match key")
+	}
+	if hasSuperclass(class) {
+		if v.root.Classes[class.Superclass] == nil {
+			v.report(path, "Superclass", "class \""+class.Superclass+"\" does not exist")
+		} else if inCycle(v.root, name) {
+			v.report(path, "Superclass", "class \""+class.Superclass+"\" forms an inheritance cycle")
+		}
+	}
+	if pd := class.PreferredDescriptor.Name; pd != "" && v.root.Classes[pd] == nil {
+		v.report(path, "PreferredDescriptor", "class \""+pd+"\" does not exist")
+	}
+	v.validateTags(path, class.Tags)
+
+	for _, mname := range sortedKeys(class.Members) {
+		member := class.Members[mname]
+		if member == nil {
+			v.report(Path{Element: "Member", Primary: name, Secondary: mname}, "", "member is nil")
+			continue
+		}
+		path := Path{Element: member.MemberType(), Primary: name, Secondary: mname}
+		if member.MemberName() != mname {
+			v.report(path, "Name", "name \""+member.MemberName()+"\" does not match key")
+		}
+		v.validateMember(path, member)
+		v.validateTags(path, member.GetTags())
+	}
+}
+
+func (v *validator) validateMember(path Path, member Member) {
+	var pd PreferredDescriptor
+	switch member := member.(type) {
+	case *Property:
+		pd = member.PreferredDescriptor
+		v.validateType(path, "ValueType", member.ValueType)
+		v.validateDefault(path, "Default", member.ValueType, member.Default)
+	case *Function:
+		pd = member.PreferredDescriptor
+		v.validateParameters(path, member.Parameters)
+		for _, typ := range member.ReturnType {
+			v.validateType(path, "ReturnType", typ)
+		}
+	case *Event:
+		pd = member.PreferredDescriptor
+		v.validateParameters(path, member.Parameters)
+	case *Callback:
+		pd = member.PreferredDescriptor
+		v.validateParameters(path, member.Parameters)
+		for _, typ := range member.ReturnType {
+			v.validateType(path, "ReturnType", typ)
+		}
+	}
+	if pd.Name != "" {
+		if m, _, _ := v.root.LookupMember(path.Primary, pd.Name); m == nil {
+			v.report(path, "PreferredDescriptor", "member \""+pd.Name+"\" does not exist")
+		}
+	}
+}
+
+func (v *validator) validateParameters(path Path, params []Parameter) {
+	for _, param := range params {
+		v.validateType(path, "Parameters", param.Type)
+		if param.Optional && param.Default == "" {
+			v.report(path, "Parameters", "optional parameter \""+param.Name+"\" has no default")
+		}
+		if param.Optional {
+			v.validateDefault(path, "Parameters", param.Type, param.Default)
+		}
+	}
+}
+
+// validateType checks that a type of the Class or Enum category refers to an
+// existing element.
+func (v *validator) validateType(path Path, field string, typ Type) {
+	switch typ.Category {
+	case "Class":
+		if v.root.Classes[typ.Name] == nil {
+			v.report(path, field, "type refers to class \""+typ.Name+"\" that does not exist")
+		}
+	case "Enum":
+		if v.root.Enums[typ.Name] == nil {
+			v.report(path, field, "type refers to enum \""+typ.Name+"\" that does not exist")
+		}
+	}
+}
+
+// validateDefault checks that a default value matches its declared type, and
+// that an enum item referred to by the value exists.
+func (v *validator) validateDefault(path Path, field string, typ Type, value string) {
+	if !v.checkDefaults {
+		return
+	}
+	d, err := ParseDefault(typ, value)
+	if err != nil {
+		v.report(path, field, err.Error())
+		return
+	}
+	item, ok := d.(EnumItemValue)
+	if !ok {
+		return
+	}
+	enum := v.root.Enums[item.Enum]
+	if enum == nil {
+		// Reported by validateType.
+		return
+	}
+	if item.Name != "" {
+		if enum.Items[item.Name] == nil {
+			v.report(path, field, "default refers to enum item \""+item.Name+"\" that does not exist")
+		}
+		return
+	}
+	for _, i := range enum.Items {
+		if i != nil && i.Value == item.Value {
+			return
+		}
+	}
+	v.report(path, field, "default refers to enum item of value "+strconv.Itoa(item.Value)+" that does not exist")
+}
+
+// validateTags checks that each tag is known and applies to the element.
+func (v *validator) validateTags(path Path, tags []string) {
+	if !v.checkTags {
+		return
+	}
+	for _, tag := range tags {
+		info, ok := tagRegistry[tag]
+		if !ok {
+			v.report(path, "Tags", "unknown tag \""+tag+"\"")
+		} else if !info.AppliesTo(path.Element) {
+			v.report(path, "Tags", "tag \""+tag+"\" does not apply to "+path.Element)
+		}
+	}
+}
+
+func (v *validator) validateEnum(name string, enum *Enum) {
+	path := EnumPath(name)
+	if enum == nil {
+		v.report(path, "", "enum is nil")
+		return
+	}
+	if enum.Name != name {
+		v.report(path, "Name", "name \""+enum.Name+"\" does not match key")
+	}
+	if pd := enum.PreferredDescriptor.Name; pd != "" && v.root.Enums[pd] == nil {
+		v.report(path, "PreferredDescriptor", "enum \""+pd+"\" does not exist")
+	}
+	v.validateTags(path, enum.Tags)
+
+	values := map[int]string{}
+	indexes := map[int]string{}
+	for _, iname := range sortedKeys(enum.Items) {
+		item := enum.Items[iname]
+		path := EnumItemPath(name, iname)
+		if item == nil {
+			v.report(path, "", "enum item is nil")
+			continue
+		}
+		if item.Name != iname {
+			v.report(path, "Name", "name \""+item.Name+"\" does not match key")
+		}
+		if other, ok := values[item.Value]; ok {
+			v.report(path, "Value", "value "+strconv.Itoa(item.Value)+" duplicates item \""+other+"\"")
+		} else {
+			values[item.Value] = iname
+		}
+		if other, ok := indexes[item.Index]; ok {
+			v.report(path, "Index", "index "+strconv.Itoa(item.Index)+" duplicates item \""+other+"\"")
+		} else {
+			indexes[item.Index] = iname
+		}
+		if pd := item.PreferredDescriptor.Name; pd != "" && enum.Items[pd] == nil {
+			v.report(path, "PreferredDescriptor", "enum item \""+pd+"\" does not exist")
+		}
+		v.validateTags(path, item.Tags)
+	}
+}
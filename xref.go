@@ -0,0 +1,155 @@
+package rbxdump
+
+import (
+	"sort"
+)
+
+// ResolveClass returns the class named by typ. Returns nil if typ is not of
+// the Class category, or if the class does not exist.
+func (root *Root) ResolveClass(typ Type) *Class {
+	if typ.Category != "Class" {
+		return nil
+	}
+	return root.Classes[typ.Name]
+}
+
+// ResolveEnum returns the enum named by typ. Returns nil if typ is not of the
+// Enum category, or if the enum does not exist.
+func (root *Root) ResolveEnum(typ Type) *Enum {
+	if typ.Category != "Enum" {
+		return nil
+	}
+	return root.Enums[typ.Name]
+}
+
+// Reference describes a use of a type by a member.
+type Reference struct {
+	// Path locates the member that uses the type.
+	Path Path
+	// Field is the name of the member field that contains the type, being one
+	// of "ValueType", "ReturnType", or "Parameters".
+	Field string
+	// Index is the position of the type within ReturnType or Parameters. Zero
+	// for ValueType.
+	Index int
+	// Type is the referring type.
+	Type Type
+}
+
+// sortReferences sorts Reference values by path, then field, then index.
+type sortReferences []Reference
+
+func (a sortReferences) Len() int      { return len(a) }
+func (a sortReferences) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a sortReferences) Less(i, j int) bool {
+	if a[i].Path != a[j].Path {
+		return a[i].Path.Less(a[j].Path)
+	}
+	if a[i].Field != a[j].Field {
+		return a[i].Field < a[j].Field
+	}
+	return a[i].Index < a[j].Index
+}
+
+// References is a precomputed index of the types used by the members of a
+// Root, mapping each class and enum to the members that refer to it. The index
+// is a snapshot; it does not reflect changes made to the Root after it was
+// created.
+type References struct {
+	classes   map[string][]Reference
+	enums     map[string][]Reference
+	undefined []Reference
+}
+
+// NewReferences returns a References indexing the members of root.
+func NewReferences(root *Root) *References {
+	r := &References{
+		classes: map[string][]Reference{},
+		enums:   map[string][]Reference{},
+	}
+	add := func(path Path, field string, index int, typ Type) {
+		ref := Reference{Path: path, Field: field, Index: index, Type: typ}
+		switch typ.Category {
+		case "Class":
+			r.classes[typ.Name] = append(r.classes[typ.Name], ref)
+			if root.Classes[typ.Name] == nil {
+				r.undefined = append(r.undefined, ref)
+			}
+		case "Enum":
+			r.enums[typ.Name] = append(r.enums[typ.Name], ref)
+			if root.Enums[typ.Name] == nil {
+				r.undefined = append(r.undefined, ref)
+			}
+		}
+	}
+	addParams := func(path Path, params []Parameter) {
+		for i, param := range params {
+			add(path, "Parameters", i, param.Type)
+		}
+	}
+	addReturns := func(path Path, types []Type) {
+		for i, typ := range types {
+			add(path, "ReturnType", i, typ)
+		}
+	}
+	for name, class := range root.Classes {
+		if class == nil {
+			continue
+		}
+		for _, member := range class.Members {
+			if member == nil {
+				continue
+			}
+			path := MemberPath(name, member)
+			switch member := member.(type) {
+			case *Property:
+				add(path, "ValueType", 0, member.ValueType)
+			case *Function:
+				addParams(path, member.Parameters)
+				addReturns(path, member.ReturnType)
+			case *Event:
+				addParams(path, member.Parameters)
+			case *Callback:
+				addParams(path, member.Parameters)
+				addReturns(path, member.ReturnType)
+			}
+		}
+	}
+	for _, refs := range r.classes {
+		sort.Sort(sortReferences(refs))
+	}
+	for _, refs := range r.enums {
+		sort.Sort(sortReferences(refs))
+	}
+	sort.Sort(sortReferences(r.undefined))
+	return r
+}
+
+// Class returns every reference to the class of the given name, ordered by
+// location. The class does not need to exist.
+func (r *References) Class(class string) []Reference {
+	return append([]Reference(nil), r.classes[class]...)
+}
+
+// Enum returns every reference to the enum of the given name, ordered by
+// location. The enum does not need to exist.
+func (r *References) Enum(enum string) []Reference {
+	return append([]Reference(nil), r.enums[enum]...)
+}
+
+// Classes returns the names of every class that is referred to, ordered by
+// name.
+func (r *References) Classes() []string {
+	return sortedKeys(r.classes)
+}
+
+// Enums returns the names of every enum that is referred to, ordered by name.
+func (r *References) Enums() []string {
+	return sortedKeys(r.enums)
+}
+
+// Undefined returns every reference to a class or enum that does not exist
+// within the indexed Root, ordered by location.
+func (r *References) Undefined() []Reference {
+	return append([]Reference(nil), r.undefined...)
+}